@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+var (
+	WrongStreamErr = errors.New("Wrong backup stream.")
+)
+
+// Backend keeps the data directory of each database in a volume of its
+// own that can be snapshotted, sent as a stream and received. Volumes
+// and snapshots are named by the database and snapshot names.
+type Backend interface {
+	Create(name string) error
+	Destroy(name string) error
+	Snapshot(name, snap string) error
+	ListSnap(name string) ([]string, error)
+	Send(name, snap string, w io.Writer) error
+	SendDiff(name, snap0, snap1 string, w io.Writer) error
+	// Recv replaces the volume with a received stream and returns the
+	// name of the snapshot it holds.
+	Recv(name string, r io.Reader) (string, error)
+	Rollback(name, snap string) error
+}
+
+// run runs a command and returns what it wrote to stderr, which is the
+// error message when it fails.
+func run(command string, stdin io.Reader, stdout io.Writer, args ...string) (string, error) {
+	cmd := exec.Command(command, args...)
+	stderr := &bytes.Buffer{}
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return stderr.String(), nil
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	btrfsCommand   = "btrfs"
+	btrfsRecvDir   = ".recv"
+	btrfsCreateDir = ".create"
+)
+
+var (
+	// the line btrfs receive writes for the subvolume it creates
+	btrfsReceived = regexp.MustCompile(`(?m)^At (?:subvol|snapshot) (.+)$`)
+)
+
+// Btrfs keeps the databases in subvolumes of a btrfs file system, the
+// data directory, and their read-only snapshots in a directory per
+// database under snapDir, which must be on the same file system but
+// outside the data directory.
+type Btrfs struct {
+	root    string
+	snapDir string
+}
+
+func NewBtrfs(root, snapDir string) *Btrfs {
+	return &Btrfs{root: root, snapDir: snapDir}
+}
+
+func (b *Btrfs) subvolume(name string) string {
+	return filepath.Join(b.root, name)
+}
+
+func (b *Btrfs) snapshots(name string) string {
+	return filepath.Join(b.snapDir, name)
+}
+
+func (b *Btrfs) snapshot(name, snap string) string {
+	return filepath.Join(b.snapDir, name, snap)
+}
+
+// Create makes the subvolume of a database. CREATE DATABASE made its
+// directory already, with a db.opt file before MySQL 8.0: the subvolume
+// is created next to the snapshots, the files of the directory are moved
+// into it and it takes the place of the directory.
+func (b *Btrfs) Create(name string) error {
+	if err := os.MkdirAll(b.snapshots(name), 0755); err != nil {
+		return err
+	}
+	tmp := filepath.Join(b.snapshots(name), btrfsCreateDir)
+	if _, err := run(btrfsCommand, nil, nil, "subvolume", "create", tmp); err != nil {
+		return err
+	}
+	err := moveFiles(b.subvolume(name), tmp)
+	if err == nil {
+		err = os.Remove(b.subvolume(name))
+	}
+	if err == nil || os.IsNotExist(err) {
+		err = os.Rename(tmp, b.subvolume(name))
+	}
+	if err != nil {
+		os.Mkdir(b.subvolume(name), 0755)
+		moveFiles(tmp, b.subvolume(name))
+		run(btrfsCommand, nil, nil, "subvolume", "delete", tmp)
+		return err
+	}
+	return nil
+}
+
+// moveFiles moves the files of the directory src, when there is one,
+// into dst.
+func moveFiles(src, dst string) error {
+	files, err := ioutil.ReadDir(src)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err = os.Rename(filepath.Join(src, file.Name()), filepath.Join(dst, file.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Btrfs) Destroy(name string) error {
+	snaps, err := b.ListSnap(name)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, snap := range snaps {
+		if _, err = run(btrfsCommand, nil, nil, "subvolume", "delete", b.snapshot(name, snap)); err != nil {
+			return err
+		}
+	}
+	if err = os.RemoveAll(b.snapshots(name)); err != nil {
+		return err
+	}
+	_, err = run(btrfsCommand, nil, nil, "subvolume", "delete", b.subvolume(name))
+	return err
+}
+
+func (b *Btrfs) Snapshot(name, snap string) error {
+	_, err := run(btrfsCommand, nil, nil, "subvolume", "snapshot", "-r", b.subvolume(name), b.snapshot(name, snap))
+	return err
+}
+
+// ListSnap returns the snapshots of a database sorted by name.
+func (b *Btrfs) ListSnap(name string) ([]string, error) {
+	files, err := ioutil.ReadDir(b.snapshots(name))
+	if err != nil {
+		return nil, err
+	}
+	snaps := make([]string, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() && file.Name() != btrfsRecvDir && file.Name() != btrfsCreateDir {
+			snaps = append(snaps, file.Name())
+		}
+	}
+	return snaps, nil
+}
+
+func (b *Btrfs) Send(name, snap string, w io.Writer) error {
+	_, err := run(btrfsCommand, nil, w, "send", b.snapshot(name, snap))
+	return err
+}
+
+func (b *Btrfs) SendDiff(name, snap0, snap1 string, w io.Writer) error {
+	_, err := run(btrfsCommand, nil, w, "send", "-p", b.snapshot(name, snap0), b.snapshot(name, snap1))
+	return err
+}
+
+// Recv receives a snapshot next to the others of the database, replacing
+// one of the same name, and makes it the database subvolume. The parent
+// of an incremental stream must be one of the snapshots.
+func (b *Btrfs) Recv(name string, r io.Reader) (string, error) {
+	dir := filepath.Join(b.snapshots(name), btrfsRecvDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	defer os.Remove(dir)
+	out, err := run(btrfsCommand, r, nil, "receive", dir)
+	if err != nil {
+		return "", err
+	}
+	m := btrfsReceived.FindStringSubmatch(out)
+	if m == nil {
+		return "", WrongStreamErr
+	}
+	snap := filepath.Base(strings.TrimSpace(m[1]))
+	if _, err = os.Stat(b.snapshot(name, snap)); err == nil {
+		if _, err = run(btrfsCommand, nil, nil, "subvolume", "delete", b.snapshot(name, snap)); err != nil {
+			return "", err
+		}
+	}
+	if err = os.Rename(filepath.Join(dir, snap), b.snapshot(name, snap)); err != nil {
+		return "", err
+	}
+	return snap, b.Rollback(name, snap)
+}
+
+// Rollback replaces the database subvolume with a writable snapshot of
+// snap.
+func (b *Btrfs) Rollback(name, snap string) error {
+	if _, err := os.Stat(b.subvolume(name)); err == nil {
+		if _, err = run(btrfsCommand, nil, nil, "subvolume", "delete", b.subvolume(name)); err != nil {
+			return err
+		}
+	}
+	_, err := run(btrfsCommand, nil, nil, "subvolume", "snapshot", b.snapshot(name, snap), b.subvolume(name))
+	return err
+}
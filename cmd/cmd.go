@@ -2,26 +2,75 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
+	"errors"
 	"github.com/freepk/mysql/frm"
-	"github.com/freepk/zfs"
 	_ "github.com/go-sql-driver/mysql"
 	"io"
 	"io/ioutil"
 	"os"
-	"strings"
+	"regexp"
+	"strconv"
+	"time"
 )
 
-func selectDb(db *sql.DB, name string) error {
-	_, err := db.Exec("USE " + name)
+var (
+	WrongNameErr = errors.New("Wrong database or snapshot name.")
+)
+
+const (
+	maxNameLength = 64
+	mysqlDriver   = "mysql"
+	dbOptFile     = "db.opt"
+	innoDBEngine  = "InnoDB"
+	// seconds to wait for the read lock of Snapshot before giving up
+	defaultLockWaitTimeout = 60
+	// snapshots taken by Restore are named by the time they are taken
+	restorePrefix     = "restore-"
+	restoreTimeFormat = "20060102T150405.000000000"
+)
+
+var (
+	dbName   = regexp.MustCompile(`^[0-9A-Za-z_]+$`)
+	snapName = regexp.MustCompile(`^[0-9A-Za-z_][0-9A-Za-z_.:-]*$`)
+)
+
+// checkName rejects database names that are not plain identifiers, so
+// they are the same in SQL, in dataset names and in the data directory.
+func checkName(name string) error {
+	if len(name) > maxNameLength || !dbName.MatchString(name) {
+		return WrongNameErr
+	}
+	return nil
+}
+
+func checkSnap(name, snap string) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+	if len(snap) > maxNameLength || !snapName.MatchString(snap) {
+		return WrongNameErr
+	}
+	return nil
+}
+
+// querier runs statements on a *sql.DB or on a single *sql.Conn.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func selectDb(ctx context.Context, db querier, name string) error {
+	_, err := db.ExecContext(ctx, "USE "+frm.QuoteIdent(name))
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func showTables(db *sql.DB) ([]string, error) {
-	rows, err := db.Query("SHOW TABLES")
+func showTables(ctx context.Context, db querier) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "SHOW TABLES")
 	if err != nil {
 		return nil, err
 	}
@@ -39,168 +88,298 @@ func showTables(db *sql.DB) ([]string, error) {
 }
 
 type Cmd struct {
-	fileSys string
-	dataDir string
-	dataSrc string
+	backend         Backend
+	driver          string
+	dataDir         string
+	dataSrc         string
+	scanner         *frm.Scanner
+	lockWaitTimeout int
 }
 
-func NewCmd(fileSys, dataDir, dataSrc string) (*Cmd, error) {
-	cmd := &Cmd{dataDir: dataDir, fileSys: fileSys, dataSrc: dataSrc}
+func NewCmd(backend Backend, dataDir, dataSrc string) (*Cmd, error) {
+	cmd := &Cmd{backend: backend, driver: mysqlDriver, dataDir: dataDir, dataSrc: dataSrc, scanner: frm.NewScanner(0),
+		lockWaitTimeout: defaultLockWaitTimeout}
 	return cmd, nil
 }
 
+// SetLockWaitTimeout sets the seconds Snapshot waits for the read lock
+// before giving up, 60 by default.
+func (c *Cmd) SetLockWaitTimeout(seconds int) {
+	c.lockWaitTimeout = seconds
+}
+
 func (c *Cmd) Create(name string) error {
-	db, err := sql.Open("mysql", c.dataSrc)
+	if err := checkName(name); err != nil {
+		return err
+	}
+	db, err := sql.Open(c.driver, c.dataSrc)
 	if err != nil {
 		return err
 	}
 	defer db.Close()
-	_, err = db.Exec("CREATE DATABASE " + name)
+	_, err = db.Exec("CREATE DATABASE " + frm.QuoteIdent(name))
 	if err != nil {
 		return err
 	}
-	err = zfs.Create(c.fileSys + "/" + name)
+	err = c.backend.Create(name)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// Drop runs its statements on one connection, the one USE selected the
+// database on.
 func (c *Cmd) Drop(name string) error {
-	db, err := sql.Open("mysql", c.dataSrc)
+	if err := checkName(name); err != nil {
+		return err
+	}
+	db, err := sql.Open(c.driver, c.dataSrc)
 	if err != nil {
 		return err
 	}
 	defer db.Close()
-	err = selectDb(db, name)
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
 	if err != nil {
 		return err
 	}
-	tables, err := showTables(db)
+	defer conn.Close()
+	err = selectDb(ctx, conn, name)
+	if err != nil {
+		return err
+	}
+	tables, err := showTables(ctx, conn)
 	if err != nil {
 		return err
 	}
 	if len(tables) > 0 {
-		_, err = db.Exec("DROP TABLE " + strings.Join(tables, ", "))
+		_, err = conn.ExecContext(ctx, "DROP TABLE "+frm.QuoteIdents(tables))
 		if err != nil {
 			return err
 		}
 	}
-	fileSys := c.fileSys + "/" + name
-	err = zfs.Destroy(fileSys, true, false)
+	err = c.backend.Destroy(name)
 	if err != nil {
 		return err
 	}
-	_, err = db.Exec("DROP DATABASE " + name)
+	_, err = conn.ExecContext(ctx, "DROP DATABASE "+frm.QuoteIdent(name))
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// Snapshot runs the statements that lock the tables on one connection,
+// so the lock is held by the session that flushes the tables and is
+// released by it, whatever step fails.
 func (c *Cmd) Snapshot(name, snap string) error {
-	db, err := sql.Open("mysql", c.dataSrc)
+	if err := checkSnap(name, snap); err != nil {
+		return err
+	}
+	db, err := sql.Open(c.driver, c.dataSrc)
 	if err != nil {
 		return err
 	}
 	defer db.Close()
-	err = selectDb(db, name)
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
 	if err != nil {
 		return err
 	}
-	_, err = db.Exec("FLUSH TABLES WITH READ LOCK")
+	defer conn.Close()
+	return c.snapshot(ctx, conn, name, snap)
+}
+
+// snapshot selects the database on conn and takes the snapshot under
+// the read lock of the connection.
+func (c *Cmd) snapshot(ctx context.Context, conn *sql.Conn, name, snap string) error {
+	_, err := conn.ExecContext(ctx, "SET SESSION lock_wait_timeout = "+strconv.Itoa(c.lockWaitTimeout))
 	if err != nil {
 		return err
 	}
-	tables, err := showTables(db)
+	err = selectDb(ctx, conn, name)
 	if err != nil {
 		return err
 	}
-	if len(tables) > 0 {
-		_, err = db.Exec("FLUSH TABLES " + strings.Join(tables, ", ") + " FOR EXPORT")
-		if err != nil {
-			return err
-		}
+	_, err = conn.ExecContext(ctx, "FLUSH TABLES WITH READ LOCK")
+	if err != nil {
+		return err
 	}
-	err = zfs.Snapshot(c.fileSys + "/" + name + "@" + snap)
+	err = c.snapshotLocked(ctx, conn, name, snap)
+	_, uerr := conn.ExecContext(ctx, "UNLOCK TABLES")
 	if err != nil {
 		return err
 	}
-	_, err = db.Exec("UNLOCK TABLES")
+	return uerr
+}
+
+// snapshotLocked flushes the tables for export and takes the snapshot
+// while the connection holds the read lock.
+func (c *Cmd) snapshotLocked(ctx context.Context, conn *sql.Conn, name, snap string) error {
+	tables, err := showTables(ctx, conn)
 	if err != nil {
 		return err
 	}
-	return nil
+	if len(tables) > 0 {
+		_, err = conn.ExecContext(ctx, "FLUSH TABLES "+frm.QuoteIdents(tables)+" FOR EXPORT")
+		if err != nil {
+			return err
+		}
+	}
+	return c.backend.Snapshot(name, snap)
 }
 
 func (c *Cmd) Backup(name, snap string, w io.Writer) error {
-	snap = c.fileSys + "/" + name + "@" + snap
-	return zfs.Send(snap, w)
+	if err := checkSnap(name, snap); err != nil {
+		return err
+	}
+	return c.backend.Send(name, snap, w)
 }
 
 func (c *Cmd) BackupDiff(name, snap0, snap1 string, w io.Writer) error {
-	snap0 = c.fileSys + "/" + name + "@" + snap0
-	snap1 = c.fileSys + "/" + name + "@" + snap1
-	return zfs.SendDiff(snap0, snap1, false, w)
+	if err := checkSnap(name, snap0); err != nil {
+		return err
+	}
+	if err := checkSnap(name, snap1); err != nil {
+		return err
+	}
+	return c.backend.SendDiff(name, snap0, snap1, w)
+}
+
+// restorePoint names the snapshot Restore takes before it drops the
+// tables, which it rolls back to when the backup cannot be restored.
+func restorePoint(t time.Time) string {
+	return restorePrefix + t.UTC().Format(restoreTimeFormat)
 }
 
+// Restore runs its statements on one connection, like Drop. The tables
+// are dropped only once a snapshot of them is taken: when the backup
+// cannot be received or holds a table that cannot be read, the database
+// is rolled back to it and its tables are created again.
 func (c *Cmd) Restore(name string, r io.Reader) error {
-	db, err := sql.Open("mysql", c.dataSrc)
+	if err := checkName(name); err != nil {
+		return err
+	}
+	db, err := sql.Open(c.driver, c.dataSrc)
 	if err != nil {
 		return err
 	}
 	defer db.Close()
-	err = selectDb(db, name)
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	before := restorePoint(time.Now())
+	err = c.snapshot(ctx, conn, name, before)
 	if err != nil {
 		return err
 	}
-	oldTables, err := showTables(db)
+	oldTables, err := showTables(ctx, conn)
 	if err != nil {
 		return err
 	}
 	if len(oldTables) > 0 {
-		_, err = db.Exec("DROP TABLE " + strings.Join(oldTables, ", "))
+		_, err = conn.ExecContext(ctx, "DROP TABLE "+frm.QuoteIdents(oldTables))
 		if err != nil {
 			return err
 		}
 	}
-	fileSys := c.fileSys + "/" + name
-	snap, err := zfs.Recv(fileSys, true, r)
+	snap, err := c.backend.Recv(name, r)
 	if err != nil {
+		c.rollback(ctx, conn, name, before, oldTables)
 		return err
 	}
-	dataDir := c.dataDir + "/" + name
+	tables, errs, err := c.readTables(name)
+	if err != nil {
+		return err
+	}
+	// a table that cannot be read would be lost with its files
+	if len(errs) > 0 {
+		c.rollback(ctx, conn, name, before, oldTables)
+		return errs[0]
+	}
+	return c.load(ctx, conn, name, snap, tables)
+}
+
+// readTables reads the definitions of the tables in the data directory
+// of a database and the errors of those that cannot be read.
+func (c *Cmd) readTables(name string) (map[string]*frm.Frm, []*frm.ScanError, error) {
+	res, err := c.scanner.ScanDir(c.dataDir+"/"+frm.EncodeFilename(name), name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.Schemas[name].Tables, res.Errors, nil
+}
+
+// rollback rolls the database back to the snapshot taken before its
+// tables were dropped and creates those of them that can be read again.
+// The error Restore returns is the one that made it roll back.
+func (c *Cmd) rollback(ctx context.Context, conn *sql.Conn, name, before string, oldTables []string) {
+	if c.backend.Rollback(name, before) != nil {
+		return
+	}
+	tables, _, err := c.readTables(name)
+	if err != nil {
+		return
+	}
+	old := make(map[string]*frm.Frm)
+	for _, table := range oldTables {
+		if def, ok := tables[table]; ok {
+			old[table] = def
+		}
+	}
+	c.load(ctx, conn, name, before, old)
+}
+
+// load creates the tables of snap, which are in the data directory, and
+// imports their files from it.
+func (c *Cmd) load(ctx context.Context, conn *sql.Conn, name, snap string, tables map[string]*frm.Frm) error {
+	dataDir := c.dataDir + "/" + frm.EncodeFilename(name)
 	files, err := ioutil.ReadDir(dataDir)
 	if err != nil {
 		return err
 	}
-	ddl := new(bytes.Buffer)
-	newTables := make(map[string]string)
+	// only the files of the tables to create are in the way, db.opt and
+	// files of other names are kept
 	for _, file := range files {
-		path := dataDir + "/" + file.Name()
-		if frm, err := frm.NewFrm(path); err == nil {
-			ddl.Reset()
-			table := strings.Split(file.Name(), ".")[0]
-			frm.WriteCreateTable(ddl, table)
-			newTables[table] = ddl.String()
+		tf, err := frm.ParseTableFile(file.Name())
+		if err != nil || tf.Temp || file.Name() == dbOptFile {
+			continue
+		}
+		if _, ok := tables[tf.Table]; ok {
+			os.Remove(dataDir + "/" + file.Name())
 		}
-		os.Remove(path)
 	}
-	for table, ddl := range newTables {
-		_, err = db.Exec(ddl)
+	// tables of other engines than InnoDB have no tablespace to discard,
+	// they are reopened on the files of the snapshot
+	ddl := new(bytes.Buffer)
+	for table, def := range tables {
+		ddl.Reset()
+		def.WriteCreateTable(ddl, table)
+		_, err = conn.ExecContext(ctx, ddl.String())
 		if err != nil {
 			return err
 		}
-		_, err = db.Exec("ALTER TABLE " + table + " DISCARD TABLESPACE")
+		if def.Engine() != innoDBEngine {
+			continue
+		}
+		_, err = conn.ExecContext(ctx, "ALTER TABLE "+frm.QuoteIdent(table)+" DISCARD TABLESPACE")
 		if err != nil {
 			return err
 		}
 	}
-	if err = zfs.Rollback(snap, true); err != nil {
+	if err = c.backend.Rollback(name, snap); err != nil {
 		return err
 	}
-	for table, _ := range newTables {
-		_, err = db.Exec("ALTER TABLE " + table + " IMPORT TABLESPACE")
+	for table, def := range tables {
+		if def.Engine() != innoDBEngine {
+			_, err = conn.ExecContext(ctx, "FLUSH TABLES "+frm.QuoteIdent(table))
+		} else {
+			_, err = conn.ExecContext(ctx, "ALTER TABLE "+frm.QuoteIdent(table)+" IMPORT TABLESPACE")
+		}
 		if err != nil {
 			return err
 		}
@@ -209,5 +388,55 @@ func (c *Cmd) Restore(name string, r io.Reader) error {
 }
 
 func (c *Cmd) ListSnap(name string) ([]string, error) {
-	return zfs.ListSnap(c.fileSys + "/" + name)
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+	return c.backend.ListSnap(name)
+}
+
+func (c *Cmd) CheckUpgrade(target string, w io.Writer) error {
+	v, err := frm.ParseVersion(target)
+	if err != nil {
+		return err
+	}
+	reports, err := frm.CheckUpgradeDataDir(c.dataDir, v)
+	if err != nil {
+		return err
+	}
+	frm.WriteUpgradeReport(w, reports)
+	return nil
+}
+
+func (c *Cmd) Lint(name string, asJSON bool, w io.Writer) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+	findings, err := frm.LintDir(c.dataDir+"/"+frm.EncodeFilename(name), nil)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		return frm.WriteFindingsJSON(w, findings)
+	}
+	frm.WriteFindings(w, findings)
+	return nil
+}
+
+func (c *Cmd) Docs(name, outDir string, format frm.DocFormat) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+	return frm.WriteDocs(c.dataDir+"/"+frm.EncodeFilename(name), name, outDir, format)
+}
+
+func (c *Cmd) CheckFiles(name string, cleanup bool, w io.Writer) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+	report, err := frm.CheckFilesDatabase(c.dataDir, name)
+	if err != nil {
+		return err
+	}
+	frm.WriteFilesReport(w, []*frm.FilesReport{report}, cleanup)
+	return nil
 }
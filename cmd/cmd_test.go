@@ -1,147 +1,733 @@
 package cmd
 
 import (
-	"log"
+	"archive/tar"
+	"bytes"
+	"errors"
+	"github.com/freepk/mysql/fakesql"
+	"io/ioutil"
 	"os"
+	"os/exec"
+	"reflect"
+	"strings"
 	"testing"
 )
 
 var (
-	cmd *Cmd
+	injectedErr = errors.New("Injected failure.")
 )
 
-const ()
+const (
+	ordersFrm = "../frm/data/Orders.frm"
+)
 
-func init() {
-	c, err := NewCmd("tank/srv2", "/var/db/mysql/tank/srv2", "root:qwer1234@tcp(localhost:33062)/")
+// testCmd returns a Cmd on a temporary data directory with an in-memory
+// backend and a fake server, with the function that removes them.
+func testCmd(t *testing.T) (*Cmd, *Memory, *fakesql.Server, func()) {
+	dataDir, err := ioutil.TempDir("", "data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mem := NewMemory()
+	server := fakesql.NewServer(dataDir)
+	c, err := NewCmd(mem, dataDir, dataDir)
 	if err != nil {
-		log.Fatal(err)
+		t.Fatal(err)
+	}
+	c.driver = fakesql.DriverName
+	return c, mem, server, func() { os.RemoveAll(dataDir) }
+}
+
+// putFrm puts the definition of the Orders table in a database
+// directory, as a backend restoring it would.
+func putFrm(t *testing.T, c *Cmd, name string) {
+	data, err := ioutil.ReadFile(ordersFrm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.MkdirAll(c.dataDir+"/"+name, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(c.dataDir+"/"+name+"/Orders.frm", data, 0644); err != nil {
+		t.Fatal(err)
 	}
-	cmd = c
 }
 
 func TestCreateDrop(t *testing.T) {
+	c, mem, server, cleanup := testCmd(t)
+	defer cleanup()
 	name := "boomoo"
-	err := cmd.Create(name)
-	if err != nil {
+	if err := c.Create(name); err != nil {
 		t.Fatal(err)
 	}
-	err = cmd.Drop(name)
-	if err != nil {
+	if !server.HasDatabase(name) {
+		t.Fatal("Database not created")
+	}
+	server.AddTable(name, "Orders")
+	server.DiscardConns()
+	start := len(server.Statements())
+	if err := c.Drop(name); err != nil {
 		t.Fatal(err)
 	}
+	checkOneConn(t, server.Statements()[start:])
+	if server.HasDatabase(name) {
+		t.Fatal("Database not dropped")
+	}
+	queries := []string{"CREATE DATABASE `boomoo`", "USE `boomoo`", "SHOW TABLES", "DROP TABLE `Orders`", "DROP DATABASE `boomoo`"}
+	if got := server.Queries(); !reflect.DeepEqual(got, queries) {
+		t.Fatal(got)
+	}
+	if got := mem.Calls(); !reflect.DeepEqual(got, []string{"Create boomoo", "Destroy boomoo"}) {
+		t.Fatal(got)
+	}
+	if err := c.Create("boo moo"); err != WrongNameErr {
+		t.Fatal("Wrong error", err)
+	}
 }
 
 func TestSnapshot(t *testing.T) {
+	c, mem, server, cleanup := testCmd(t)
+	defer cleanup()
 	name := "boomoo"
-	snap0 := "first"
-	snap1 := "second"
-	err := cmd.Create(name)
-	if err != nil {
+	if err := c.Create(name); err != nil {
 		t.Fatal(err)
 	}
-	err = cmd.Snapshot(name, snap0)
-	if err != nil {
+	server.AddTable(name, "OrderDetails")
+	server.AddTable(name, "Orders")
+	server.DiscardConns()
+	start := len(server.Statements())
+	if err := c.Snapshot(name, "first"); err != nil {
 		t.Fatal(err)
 	}
-	err = cmd.Snapshot(name, snap1)
-	if err != nil {
-		t.Fatal(err)
+	queries := []string{"SET SESSION lock_wait_timeout = 60", "USE `boomoo`", "FLUSH TABLES WITH READ LOCK", "SHOW TABLES",
+		"FLUSH TABLES `OrderDetails`, `Orders` FOR EXPORT", "UNLOCK TABLES"}
+	if got := server.Queries()[start:]; !reflect.DeepEqual(got, queries) {
+		t.Fatal(got)
 	}
-	err = cmd.Drop(name)
-	if err != nil {
+	if got := mem.Calls(); !reflect.DeepEqual(got, []string{"Create boomoo", "Snapshot boomoo first"}) {
+		t.Fatal(got)
+	}
+	c.SetLockWaitTimeout(5)
+	start = len(server.Statements())
+	if err := c.Snapshot(name, "second"); err != nil {
 		t.Fatal(err)
 	}
+	if got := server.Queries()[start]; got != "SET SESSION lock_wait_timeout = 5" {
+		t.Fatal(got)
+	}
+	if err := c.Snapshot(name, "-first"); err != WrongNameErr {
+		t.Fatal("Wrong error", err)
+	}
+	if server.OpenConns() != 0 {
+		t.Fatal("Connections not closed", server.OpenConns())
+	}
+}
+
+// checkOneConn checks that statements ran on one connection, so the
+// unqualified ones apply to the database USE selected.
+func checkOneConn(t *testing.T, statements []fakesql.Statement) {
+	for _, st := range statements {
+		if st.Conn != statements[0].Conn {
+			t.Fatal("Statements on several connections", statements)
+		}
+	}
+}
+
+// TestSnapshotUnlock checks the session that takes the read lock
+// releases it whatever step fails.
+func TestSnapshotUnlock(t *testing.T) {
+	for _, query := range []string{"SHOW TABLES", "FLUSH TABLES `", ""} {
+		c, mem, server, cleanup := testCmd(t)
+		name := "boomoo"
+		if err := c.Create(name); err != nil {
+			t.Fatal(err)
+		}
+		server.AddTable(name, "Orders")
+		server.DiscardConns()
+		if query != "" {
+			server.Fail(query, injectedErr)
+		} else {
+			mem.Fail("Snapshot", injectedErr)
+		}
+		start := len(server.Statements())
+		if err := c.Snapshot(name, "first"); err != injectedErr {
+			t.Fatal("Wrong error", err)
+		}
+		statements := server.Statements()[start:]
+		checkOneConn(t, statements)
+		if last := statements[len(statements)-1]; last.Query != "UNLOCK TABLES" || last.Err != nil {
+			t.Fatal("Tables not unlocked", statements)
+		}
+		if server.OpenConns() != 0 {
+			t.Fatal("Connections not closed", server.OpenConns())
+		}
+		cleanup()
+	}
 }
 
 func TestBackup(t *testing.T) {
+	c, mem, _, cleanup := testCmd(t)
+	defer cleanup()
 	name := "boomoo"
-	snap0 := "first"
-	snap1 := "second"
-	back0, _ := os.Create("test-dump-full")
-	defer back0.Close()
-	back1, _ := os.Create("test-dump-diff")
-	defer back1.Close()
-
-	err := cmd.Create(name)
-	if err != nil {
+	if err := c.Create(name); err != nil {
 		t.Fatal(err)
 	}
-	err = cmd.Snapshot(name, snap0)
-	if err != nil {
+	for _, snap := range []string{"first", "second"} {
+		if err := c.Snapshot(name, snap); err != nil {
+			t.Fatal(err)
+		}
+	}
+	full, diff := &bytes.Buffer{}, &bytes.Buffer{}
+	if err := c.Backup(name, "first", full); err != nil {
 		t.Fatal(err)
 	}
-	err = cmd.Snapshot(name, snap1)
-	if err != nil {
+	if err := c.BackupDiff(name, "first", "second", diff); err != nil {
 		t.Fatal(err)
 	}
-	err = cmd.Backup(name, snap0, back0)
-	if err != nil {
+	if full.Len() == 0 || diff.Len() == 0 {
+		t.Fatal("Empty backup")
+	}
+	if err := c.Backup(name, "third", full); !os.IsNotExist(err) {
+		t.Fatal("Wrong error", err)
+	}
+	calls := mem.Calls()
+	if got := calls[len(calls)-2:]; !reflect.DeepEqual(got, []string{"SendDiff boomoo first second", "Send boomoo third"}) {
+		t.Fatal(got)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	c, mem, server, cleanup := testCmd(t)
+	defer cleanup()
+	name, clone := "boomoo", "boomoo_clone"
+	for _, name := range []string{name, clone} {
+		if err := c.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, snap := range []string{"first", "second"} {
+		if err := c.Snapshot(name, snap); err != nil {
+			t.Fatal(err)
+		}
+	}
+	full, diff := &bytes.Buffer{}, &bytes.Buffer{}
+	if err := c.Backup(name, "first", full); err != nil {
 		t.Fatal(err)
 	}
-	err = cmd.BackupDiff(name, snap0, snap1, back1)
-	if err != nil {
+	if err := c.BackupDiff(name, "first", "second", diff); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Restore(clone, bytes.NewReader(diff.Bytes())); err != NoParentErr {
+		t.Fatal("Wrong error", err)
+	}
+	stream := full.Bytes()
+	server.DiscardConns()
+	for _, r := range []*bytes.Buffer{full, diff} {
+		putFrm(t, c, clone)
+		for _, file := range []string{"db.opt", "#sql-1a2b_3.frm", "notes@@.txt"} {
+			if err := ioutil.WriteFile(c.dataDir+"/"+clone+"/"+file, nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		start := len(server.Queries())
+		if err := c.Restore(clone, r); err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range []string{"db.opt", "#sql-1a2b_3.frm", "notes@@.txt"} {
+			if _, err := os.Stat(c.dataDir + "/" + clone + "/" + file); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := os.Stat(c.dataDir + "/" + clone + "/Orders.frm"); !os.IsNotExist(err) {
+			t.Fatal("Table file not removed", err)
+		}
+		checkOneConn(t, server.Statements()[start:])
+		queries := server.Queries()[start:]
+		if len(queries) < 5 || !strings.HasPrefix(queries[len(queries)-3], "CREATE TABLE `Orders`") ||
+			queries[len(queries)-2] != "ALTER TABLE `Orders` DISCARD TABLESPACE" ||
+			queries[len(queries)-1] != "ALTER TABLE `Orders` IMPORT TABLESPACE" {
+			t.Fatal(queries)
+		}
+	}
+	if got := server.Tables(clone); !reflect.DeepEqual(got, []string{"Orders"}) {
+		t.Fatal(got)
+	}
+	// the old tables are rolled back to when a table cannot be read
+	putFrm(t, c, clone)
+	data, _ := ioutil.ReadFile(ordersFrm)
+	if err := ioutil.WriteFile(c.dataDir+"/"+clone+"/broken.frm", data[:300], 0644); err != nil {
 		t.Fatal(err)
 	}
-	err = cmd.Drop(name)
+	start := len(mem.Calls())
+	if err := c.Restore(clone, bytes.NewReader(stream)); err == nil {
+		t.Fatal("Broken table restored")
+	}
+	if got := server.Tables(clone); !reflect.DeepEqual(got, []string{"Orders"}) {
+		t.Fatal("Old tables not kept", got)
+	}
+	calls := mem.Calls()[start:]
+	if len(calls) != 4 || !strings.HasPrefix(calls[0], "Snapshot boomoo_clone "+restorePrefix) ||
+		calls[1] != "Recv boomoo_clone" || calls[2] != "Rollback"+calls[0][len("Snapshot"):] || calls[3] != calls[2] {
+		t.Fatal(calls)
+	}
+	list, err := c.ListSnap(clone)
 	if err != nil {
 		t.Fatal(err)
 	}
+	received := make([]string, 0)
+	for _, snap := range list {
+		if !strings.HasPrefix(snap, restorePrefix) {
+			received = append(received, snap)
+		}
+	}
+	if len(list) != 6 || !reflect.DeepEqual(received, []string{"first", "second"}) {
+		t.Fatal(list)
+	}
 }
 
-func TestRestore(t *testing.T) {
+// TestRestoreOptions checks the restored tables keep their engine and
+// the options the engine keeps in files of its own.
+func TestRestoreOptions(t *testing.T) {
+	c, _, server, cleanup := testCmd(t)
+	defer cleanup()
 	name := "boomoo"
-	err := cmd.Create(name)
-	if err != nil {
+	if err := c.Create(name); err != nil {
 		t.Fatal(err)
 	}
-	full, err := os.Open("./full-dump")
-	if err != nil {
+	if err := c.Snapshot(name, "first"); err != nil {
 		t.Fatal(err)
 	}
-	defer full.Close()
-	err = cmd.Restore(name, full)
-	if err != nil {
+	full := &bytes.Buffer{}
+	if err := c.Backup(name, "first", full); err != nil {
 		t.Fatal(err)
 	}
-	diff, err := os.Open("./diff-dump")
-	if err != nil {
+	os.MkdirAll(c.dataDir+"/"+name, 0755)
+	for _, file := range []string{"m.frm", "m.MRG", "r.frm", "r.isl"} {
+		data, err := ioutil.ReadFile("../frm/data/engine/" + file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(c.dataDir+"/"+name+"/"+file, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start := len(server.Queries())
+	if err := c.Restore(name, full); err != nil {
 		t.Fatal(err)
 	}
-	defer diff.Close()
-	err = cmd.Restore(name, diff)
-	if err != nil {
+	queries := strings.Join(server.Queries()[start:], "\n")
+	for _, want := range []string{
+		") ENGINE=MRG_MYISAM DEFAULT CHARSET=latin1 INSERT_METHOD=LAST UNION=(`t1`,`db2`.`t2`)",
+		") ENGINE=InnoDB DEFAULT CHARSET=latin1 DATA DIRECTORY='/data/remote/'",
+		"ALTER TABLE `r` DISCARD TABLESPACE",
+		"ALTER TABLE `r` IMPORT TABLESPACE",
+		"FLUSH TABLES `m`",
+	} {
+		if !strings.Contains(queries, want) {
+			t.Error("No", want, "in", queries)
+		}
+	}
+	if strings.Contains(queries, "ALTER TABLE `m`") {
+		t.Error("Tablespace of MERGE table discarded", queries)
+	}
+}
+
+func TestSnapList(t *testing.T) {
+	c, _, _, cleanup := testCmd(t)
+	defer cleanup()
+	name := "boomoo"
+	if err := c.Create(name); err != nil {
 		t.Fatal(err)
 	}
-	err = cmd.Drop(name)
+	for _, snap := range []string{"first", "second"} {
+		if err := c.Snapshot(name, snap); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if list, err := c.ListSnap(name); err != nil || !reflect.DeepEqual(list, []string{"first", "second"}) {
+		t.Fatal(list, err)
+	}
+	if _, err := c.ListSnap("nobody"); !os.IsNotExist(err) {
+		t.Fatal("Wrong error", err)
+	}
+}
+
+// TestFailures fails each step of the commands, a statement or a backend
+// call, and checks the command returns the error.
+func TestFailures(t *testing.T) {
+	create := func(c *Cmd, r *bytes.Buffer) error { return c.Create("boomoo_new") }
+	drop := func(c *Cmd, r *bytes.Buffer) error { return c.Drop("boomoo") }
+	snapshot := func(c *Cmd, r *bytes.Buffer) error { return c.Snapshot("boomoo", "third") }
+	backup := func(c *Cmd, r *bytes.Buffer) error { return c.Backup("boomoo", "first", &bytes.Buffer{}) }
+	backupDiff := func(c *Cmd, r *bytes.Buffer) error {
+		return c.BackupDiff("boomoo", "first", "second", &bytes.Buffer{})
+	}
+	restore := func(c *Cmd, r *bytes.Buffer) error { return c.Restore("boomoo", r) }
+	listSnap := func(c *Cmd, r *bytes.Buffer) error {
+		_, err := c.ListSnap("boomoo")
+		return err
+	}
+	for _, test := range []struct {
+		query  string
+		method string
+		run    func(c *Cmd, r *bytes.Buffer) error
+	}{
+		{"CREATE DATABASE", "", create},
+		{"", "Create", create},
+		{"USE", "", drop},
+		{"SHOW TABLES", "", drop},
+		{"DROP TABLE", "", drop},
+		{"", "Destroy", drop},
+		{"DROP DATABASE", "", drop},
+		{"SET SESSION lock_wait_timeout", "", snapshot},
+		{"USE", "", snapshot},
+		{"FLUSH TABLES WITH READ LOCK", "", snapshot},
+		{"SHOW TABLES", "", snapshot},
+		{"FLUSH TABLES `", "", snapshot},
+		{"", "Snapshot", snapshot},
+		{"UNLOCK TABLES", "", snapshot},
+		{"", "Send", backup},
+		{"", "SendDiff", backupDiff},
+		{"USE", "", restore},
+		{"SHOW TABLES", "", restore},
+		{"DROP TABLE", "", restore},
+		{"", "Recv", restore},
+		{"CREATE TABLE", "", restore},
+		{"ALTER TABLE `Orders` DISCARD", "", restore},
+		{"", "Rollback", restore},
+		{"ALTER TABLE `Orders` IMPORT", "", restore},
+		{"", "ListSnap", listSnap},
+	} {
+		c, mem, server, cleanup := testCmd(t)
+		name := "boomoo"
+		if err := c.Create(name); err != nil {
+			t.Fatal(err)
+		}
+		server.AddTable(name, "Orders")
+		for _, snap := range []string{"first", "second"} {
+			if err := c.Snapshot(name, snap); err != nil {
+				t.Fatal(err)
+			}
+		}
+		full := &bytes.Buffer{}
+		if err := c.Backup(name, "first", full); err != nil {
+			t.Fatal(err)
+		}
+		putFrm(t, c, name)
+		if test.query != "" {
+			server.Fail(test.query, injectedErr)
+		} else {
+			mem.Fail(test.method, injectedErr)
+		}
+		if err := test.run(c, full); err != injectedErr {
+			t.Error(test.query, test.method, ": wrong error ", err)
+		}
+		cleanup()
+	}
+}
+
+// mountBtrfs mounts a loopback btrfs image in a temporary directory and
+// returns it with the function that unmounts it.
+func mountBtrfs(t *testing.T) (string, func()) {
+	if os.Geteuid() != 0 {
+		t.Skip("btrfs tests need root")
+	}
+	if _, err := exec.LookPath("mkfs.btrfs"); err != nil {
+		t.Skip("btrfs tests need btrfs-progs")
+	}
+	dir, err := ioutil.TempDir("", "btrfs")
 	if err != nil {
 		t.Fatal(err)
 	}
+	image := dir + "/image"
+	mnt := dir + "/mnt"
+	cleanup := func() { os.RemoveAll(dir) }
+	for _, args := range [][]string{
+		{"truncate", "-s", "128M", image},
+		{"mkfs.btrfs", "-q", image},
+		{"mkdir", mnt},
+		{"mount", "-o", "loop", image, mnt},
+	} {
+		if out, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+			cleanup()
+			t.Skip(args[0], ": ", string(out))
+		}
+	}
+	return mnt, func() {
+		exec.Command("umount", mnt).Run()
+		cleanup()
+	}
 }
 
-func TestSnapList(t *testing.T) {
+func TestBtrfs(t *testing.T) {
+	mnt, cleanup := mountBtrfs(t)
+	defer cleanup()
+	os.Mkdir(mnt+"/data", 0755)
+	b := NewBtrfs(mnt+"/data", mnt+"/snapshots")
 	name := "boomoo"
-	snap0 := "first"
-	snap1 := "second"
-	err := cmd.Create(name)
+	// the directory and db.opt file CREATE DATABASE made are kept
+	os.Mkdir(mnt+"/data/"+name, 0755)
+	ioutil.WriteFile(mnt+"/data/"+name+"/db.opt", nil, 0644)
+	if err := b.Create(name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(mnt + "/data/" + name + "/db.opt"); err != nil {
+		t.Fatal(err)
+	}
+	path := mnt + "/data/" + name + "/t.ibd"
+	if err := ioutil.WriteFile(path, []byte("first"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Snapshot(name, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte("second"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Snapshot(name, "second"); err != nil {
+		t.Fatal(err)
+	}
+	if list, err := b.ListSnap(name); err != nil || len(list) != 2 {
+		t.Fatal(list, err)
+	}
+	full, diff := &bytes.Buffer{}, &bytes.Buffer{}
+	if err := b.Send(name, "first", full); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SendDiff(name, "first", "second", diff); err != nil {
+		t.Fatal(err)
+	}
+	clone := "boomoo_clone"
+	if err := b.Create(clone); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []*bytes.Buffer{full, diff} {
+		if _, err := b.Recv(clone, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if data, err := ioutil.ReadFile(mnt + "/data/" + clone + "/t.ibd"); err != nil || string(data) != "second" {
+		t.Fatal(string(data), err)
+	}
+	if err := b.Rollback(clone, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ioutil.ReadFile(mnt + "/data/" + clone + "/t.ibd"); err != nil || string(data) != "first" {
+		t.Fatal(string(data), err)
+	}
+	for _, name := range []string{name, clone} {
+		if err := b.Destroy(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDir(t *testing.T) {
+	root, err := ioutil.TempDir("", "dir")
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = cmd.Snapshot(name, snap0)
+	defer os.RemoveAll(root)
+	d := NewDir(root+"/data", root+"/snapshots")
+	os.Mkdir(root+"/data", 0755)
+	name, clone := "boomoo", "boomoo_clone"
+	if err = d.Create(name); err != nil {
+		t.Fatal(err)
+	}
+	dir := root + "/data/" + name
+	ioutil.WriteFile(dir+"/t1.ibd", []byte("first"), 0644)
+	ioutil.WriteFile(dir+"/t2.ibd", []byte("kept"), 0644)
+	ioutil.WriteFile(dir+"/t3.ibd", []byte("dropped"), 0644)
+	if err = d.Snapshot(name, "first"); err != nil {
+		t.Fatal(err)
+	}
+	ioutil.WriteFile(dir+"/t1.ibd", []byte("second"), 0644)
+	os.Remove(dir + "/t3.ibd")
+	if err = d.Snapshot(name, "second"); err != nil {
+		t.Fatal(err)
+	}
+	if list, err := d.ListSnap(name); err != nil || len(list) != 2 || list[0] != "first" {
+		t.Fatal(list, err)
+	}
+	kept0, _ := os.Stat(root + "/snapshots/" + name + "/first/t2.ibd")
+	kept1, _ := os.Stat(root + "/snapshots/" + name + "/second/t2.ibd")
+	if !os.SameFile(kept0, kept1) {
+		t.Fatal("Unchanged file not linked")
+	}
+	full, diff := &bytes.Buffer{}, &bytes.Buffer{}
+	if err = d.Send(name, "first", full); err != nil {
+		t.Fatal(err)
+	}
+	if err = d.SendDiff(name, "first", "second", diff); err != nil {
+		t.Fatal(err)
+	}
+	if err = d.Create(clone); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = d.Recv(clone, bytes.NewReader(diff.Bytes())); err != NoParentErr {
+		t.Fatal("Wrong error", err)
+	}
+	for _, r := range []*bytes.Buffer{full, diff} {
+		if _, err = d.Recv(clone, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if list, err := d.ListSnap(clone); err != nil || len(list) != 2 || list[0] != "first" || list[1] != "second" {
+		t.Fatal(list, err)
+	}
+	evil := &bytes.Buffer{}
+	tw := tar.NewWriter(evil)
+	writeBackupHeader(tw, &fileBackup{Snapshot: "evil"})
+	os.Mkdir(root+"/outside", 0755)
+	tw.WriteHeader(&tar.Header{Name: "out", Typeflag: tar.TypeSymlink, Linkname: root + "/outside", Mode: 0777})
+	tw.WriteHeader(&tar.Header{Name: "out/x", Typeflag: tar.TypeReg, Mode: 0644})
+	tw.Close()
+	if _, err = d.Recv(clone, evil); err != WrongStreamErr {
+		t.Fatal("Wrong error", err)
+	}
+	if _, err = os.Stat(root + "/outside/x"); !os.IsNotExist(err) {
+		t.Fatal("File written through a link", err)
+	}
+	dir = root + "/data/" + clone
+	if data, err := ioutil.ReadFile(dir + "/t1.ibd"); err != nil || string(data) != "second" {
+		t.Fatal(string(data), err)
+	}
+	if _, err = os.Stat(dir + "/t3.ibd"); !os.IsNotExist(err) {
+		t.Fatal("Deleted file restored", err)
+	}
+	if err = d.Rollback(clone, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ioutil.ReadFile(dir + "/t3.ibd"); err != nil || string(data) != "dropped" {
+		t.Fatal(string(data), err)
+	}
+	for _, name := range []string{name, clone} {
+		if err = d.Destroy(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the server makes the directory before the backend does
+	c, err := NewCmd(d, root+"/data", root+"/data")
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = cmd.Snapshot(name, snap1)
+	c.driver = fakesql.DriverName
+	fakesql.NewServer(root + "/data").MakeDirs(root + "/data")
+	if err = c.Create(name); err != nil {
+		t.Fatal(err)
+	}
+	if list, err := c.ListSnap(name); err != nil || len(list) != 0 {
+		t.Fatal(list, err)
+	}
+}
+
+// lvmPool creates a volume group with a thin pool on a loop device and
+// returns its name with the function that removes it.
+func lvmPool(t *testing.T) (string, string, func()) {
+	if os.Geteuid() != 0 {
+		t.Skip("LVM tests need root")
+	}
+	if _, err := exec.LookPath("lvcreate"); err != nil {
+		t.Skip("LVM tests need lvm2")
+	}
+	dir, err := ioutil.TempDir("", "lvm")
 	if err != nil {
 		t.Fatal(err)
 	}
-	if list, err := cmd.ListSnap(name); err != nil {
+	image := dir + "/image"
+	vg := "mysqltest"
+	if err = exec.Command("truncate", "-s", "512M", image).Run(); err != nil {
+		os.RemoveAll(dir)
 		t.Fatal(err)
-	} else {
-		t.Log(list)
 	}
-	err = cmd.Drop(name)
+	out, err := exec.Command("losetup", "-f", "--show", image).Output()
 	if err != nil {
+		os.RemoveAll(dir)
+		t.Skip("no loop device")
+	}
+	loop := strings.TrimSpace(string(out))
+	cleanup := func() {
+		exec.Command("vgremove", "-f", vg).Run()
+		exec.Command("pvremove", loop).Run()
+		exec.Command("losetup", "-d", loop).Run()
+		os.RemoveAll(dir)
+	}
+	for _, args := range [][]string{
+		{"pvcreate", loop},
+		{"vgcreate", vg, loop},
+		{"lvcreate", "-L", "400M", "-T", vg + "/pool"},
+	} {
+		if out, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+			cleanup()
+			t.Fatal(args[0], ": ", string(out))
+		}
+	}
+	return vg, dir, cleanup
+}
+
+func TestLVM(t *testing.T) {
+	vg, dir, cleanup := lvmPool(t)
+	defer cleanup()
+	l := NewLVM(vg, "pool", "64M", "ext4", dir+"/data", dir+"/mnt")
+	name, clone := "boomoo", "boomoo_clone"
+	if err := l.Create(name); err != nil {
 		t.Fatal(err)
 	}
+	path := dir + "/data/" + name + "/t.ibd"
+	ioutil.WriteFile(path, []byte("first"), 0644)
+	if err := l.Snapshot(name, "first"); err != nil {
+		t.Fatal(err)
+	}
+	ioutil.WriteFile(path, []byte("second"), 0644)
+	if err := l.Snapshot(name, "second"); err != nil {
+		t.Fatal(err)
+	}
+	if list, err := l.ListSnap(name); err != nil || len(list) != 2 || list[0] != "first" {
+		t.Fatal(list, err)
+	}
+	if _, err := l.ListSnap(name + "_recv"); !os.IsNotExist(err) {
+		t.Fatal("Wrong error", err)
+	}
+	full, diff := &bytes.Buffer{}, &bytes.Buffer{}
+	if err := l.Send(name, "first", full); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SendDiff(name, "first", "second", diff); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Create(clone); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []*bytes.Buffer{full, diff} {
+		if _, err := l.Recv(clone, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if data, err := ioutil.ReadFile(dir + "/data/" + clone + "/t.ibd"); err != nil || string(data) != "second" {
+		t.Fatal(string(data), err)
+	}
+	// the volume is kept and mounted again when it cannot be renamed aside
+	if out, err := exec.Command("lvcreate", "-V", "64M", "-T", vg+"/pool", "-n", clone+lvmOldSuffix).CombinedOutput(); err != nil {
+		t.Fatal(string(out))
+	}
+	if err := l.Rollback(clone, "first"); err == nil {
+		t.Fatal("Volume replaced")
+	}
+	if data, err := ioutil.ReadFile(dir + "/data/" + clone + "/t.ibd"); err != nil || string(data) != "second" {
+		t.Fatal(string(data), err)
+	}
+	if out, err := exec.Command("lvremove", "-y", vg+"/"+clone+lvmOldSuffix).CombinedOutput(); err != nil {
+		t.Fatal(string(out))
+	}
+	if err := l.Rollback(clone, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ioutil.ReadFile(dir + "/data/" + clone + "/t.ibd"); err != nil || string(data) != "first" {
+		t.Fatal(string(data), err)
+	}
+	for _, name := range []string{name, clone} {
+		if err := l.Destroy(name); err != nil {
+			t.Fatal(err)
+		}
+	}
 }
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	NoParentErr = errors.New("Parent snapshot of incremental backup not found.")
+)
+
+const (
+	dirTempPrefix   = "."
+	dirRecvTemp     = ".recv"
+	dirRollbackTemp = ".rollback"
+)
+
+// Dir keeps the databases in plain directories of the data directory
+// and their snapshots in a directory per database under snapDir, which
+// must be on the same file system. Snapshot files are hard links to the
+// previous snapshot when they did not change and reflink copies, or
+// full copies where the file system has no reflinks, otherwise.
+type Dir struct {
+	root    string
+	snapDir string
+}
+
+func NewDir(root, snapDir string) *Dir {
+	return &Dir{root: root, snapDir: snapDir}
+}
+
+func (d *Dir) dir(name string) string {
+	return filepath.Join(d.root, name)
+}
+
+func (d *Dir) snapshots(name string) string {
+	return filepath.Join(d.snapDir, name)
+}
+
+func (d *Dir) snapshot(name, snap string) string {
+	return filepath.Join(d.snapDir, name, snap)
+}
+
+// Create makes the snapshot directory of a database and its directory,
+// unless CREATE DATABASE made it already.
+func (d *Dir) Create(name string) error {
+	if err := os.MkdirAll(d.snapshots(name), 0755); err != nil {
+		return err
+	}
+	if err := os.Mkdir(d.dir(name), 0755); err != nil && !os.IsExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (d *Dir) Destroy(name string) error {
+	if err := os.RemoveAll(d.snapshots(name)); err != nil {
+		return err
+	}
+	return os.RemoveAll(d.dir(name))
+}
+
+func (d *Dir) Snapshot(name, snap string) error {
+	link := ""
+	if snaps, err := d.ListSnap(name); err == nil && len(snaps) > 0 {
+		link = d.snapshot(name, snaps[len(snaps)-1])
+	}
+	return d.replace(d.snapshot(name, snap), func(tmp string) error {
+		return copyTree(d.dir(name), tmp, link)
+	})
+}
+
+// ListSnap returns the snapshots of a database sorted by name, like
+// those of Btrfs: the modification times of the directories change with
+// Recv and copies.
+func (d *Dir) ListSnap(name string) ([]string, error) {
+	files, err := ioutil.ReadDir(d.snapshots(name))
+	if err != nil {
+		return nil, err
+	}
+	snaps := make([]string, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() && !strings.HasPrefix(file.Name(), dirTempPrefix) {
+			snaps = append(snaps, file.Name())
+		}
+	}
+	return snaps, nil
+}
+
+// Send writes a snapshot as a tar stream.
+func (d *Dir) Send(name, snap string, w io.Writer) error {
+	return sendDir(d.snapshot(name, snap), snap, w)
+}
+
+// SendDiff writes the files of snap1 that are not in snap0 or changed
+// since, as a tar stream that lists the removed files as well.
+func (d *Dir) SendDiff(name, snap0, snap1 string, w io.Writer) error {
+	return sendDirDiff(d.snapshot(name, snap0), d.snapshot(name, snap1), snap0, snap1, w)
+}
+
+// Recv extracts a backup stream into a snapshot of the database,
+// replacing one of the same name, and rolls the database back to it. The
+// parent of an incremental backup must be one of the snapshots.
+func (d *Dir) Recv(name string, r io.Reader) (string, error) {
+	tr := tar.NewReader(r)
+	backup, err := readBackupHeader(tr)
+	if err != nil {
+		return "", err
+	}
+	parent := ""
+	if backup.Parent != "" {
+		parent = d.snapshot(name, backup.Parent)
+		if _, err = os.Stat(parent); err != nil {
+			return "", NoParentErr
+		}
+	}
+	err = d.replace(d.snapshot(name, backup.Snapshot), func(tmp string) error {
+		if parent != "" {
+			if err := copyTree(parent, tmp, parent); err != nil {
+				return err
+			}
+		} else if err := os.Mkdir(tmp, 0755); err != nil {
+			return err
+		}
+		return applyBackup(tr, backup, tmp)
+	})
+	if err != nil {
+		return "", err
+	}
+	return backup.Snapshot, d.Rollback(name, backup.Snapshot)
+}
+
+// Rollback replaces the database directory with a copy of snap. The copy
+// does not share files with the snapshot, it is written in place.
+func (d *Dir) Rollback(name, snap string) error {
+	tmp := filepath.Join(d.snapshots(name), dirRollbackTemp)
+	os.RemoveAll(tmp)
+	if err := copyTree(d.snapshot(name, snap), tmp, ""); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+	if err := os.RemoveAll(d.dir(name)); err != nil {
+		return err
+	}
+	return os.Rename(tmp, d.dir(name))
+}
+
+// replace writes a snapshot into a temporary directory with fn and puts
+// it in place of dir, so a failure leaves no partial snapshot.
+func (d *Dir) replace(dir string, fn func(tmp string) error) error {
+	tmp := filepath.Join(filepath.Dir(dir), dirRecvTemp)
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return err
+	}
+	os.RemoveAll(tmp)
+	if err := fn(tmp); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dir)
+}
+
+// sameFile reports whether a snapshot file did not change since an older
+// snapshot: copies keep the size and modification time.
+func sameFile(a, b os.FileInfo) bool {
+	if a.IsDir() || b.IsDir() {
+		return a.IsDir() && b.IsDir()
+	}
+	return os.SameFile(a, b) || (a.Mode() == b.Mode() && a.Size() == b.Size() && a.ModTime().Equal(b.ModTime()))
+}
+
+// copyTree copies the directory src to dst. Regular files that did not
+// change since their copy in link, when given, are hard linked to it.
+func copyTree(src, dst, link string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		target := filepath.Join(dst, rel)
+		switch {
+		case info.IsDir():
+			return os.Mkdir(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			s, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(s, target)
+		case !info.Mode().IsRegular():
+			return nil
+		}
+		if link != "" {
+			old := filepath.Join(link, rel)
+			if oldInfo, err := os.Lstat(old); err == nil && sameFile(oldInfo, info) {
+				return os.Link(old, target)
+			}
+		}
+		return copyFile(path, target, info)
+	})
+}
+
+// copyFile copies a regular file with its mode and modification time,
+// as a reflink when the file system can.
+func copyFile(src, dst string, info os.FileInfo) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if reflink(out, in) != nil {
+		_, err = io.Copy(out, in)
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
+}
@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	lvmDatabaseTag = "mysql_db="
+	lvmSnapshotTag = "mysql_snap="
+	// separates the database name in the names of its other volumes,
+	// database names cannot have it
+	lvmNameSep    = "."
+	lvmRecvSuffix = lvmNameSep + "recv"
+	lvmOldSuffix  = lvmNameSep + "old"
+	xfsFileSystem = "xfs"
+)
+
+// LVM keeps each database in a thin volume of a thin pool, with a file
+// system mounted at its directory in the data directory, and snapshots
+// as thin snapshots tagged with the database and snapshot names. Backups
+// are file level streams like those of Dir, read from snapshots mounted
+// under mntDir. The mounts are not written to fstab.
+type LVM struct {
+	vg     string
+	pool   string
+	size   string
+	fsType string
+	root   string
+	mntDir string
+}
+
+// lvmVolume is a snapshot volume of a database.
+type lvmVolume struct {
+	name string
+	snap string
+}
+
+// NewLVM returns the backend for the thin pool of a volume group. Volumes
+// have the virtual size given in lvcreate units, like "10G", and a file
+// system of fsType.
+func NewLVM(vg, pool, size, fsType, root, mntDir string) *LVM {
+	return &LVM{vg: vg, pool: pool, size: size, fsType: fsType, root: root, mntDir: mntDir}
+}
+
+func (l *LVM) dir(name string) string {
+	return filepath.Join(l.root, name)
+}
+
+func (l *LVM) lv(name string) string {
+	return l.vg + "/" + name
+}
+
+func (l *LVM) dev(name string) string {
+	return "/dev/" + l.vg + "/" + name
+}
+
+// mount mounts a volume, XFS volumes without checking the UUID that
+// snapshots share with their origin.
+func (l *LVM) mount(lv, dir string, readOnly bool) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	opts := make([]string, 0, 2)
+	if readOnly {
+		opts = append(opts, "ro")
+	}
+	if l.fsType == xfsFileSystem {
+		opts = append(opts, "nouuid")
+	}
+	args := []string{l.dev(lv), dir}
+	if len(opts) > 0 {
+		args = append([]string{"-o", strings.Join(opts, ",")}, args...)
+	}
+	_, err := run("mount", nil, nil, args...)
+	return err
+}
+
+func (l *LVM) umount(dir string) error {
+	_, err := run("umount", nil, nil, dir)
+	return err
+}
+
+// snapshots returns the snapshot volumes of a database, oldest first.
+func (l *LVM) snapshots(name string) ([]lvmVolume, error) {
+	out := &strings.Builder{}
+	_, err := run("lvs", nil, out, "--noheadings", "--separator", ";", "-o", "lv_name,lv_tags",
+		"-O", "lv_time", "@"+lvmDatabaseTag+name)
+	if err != nil {
+		return nil, err
+	}
+	vols := make([]lvmVolume, 0)
+	for _, line := range strings.Split(out.String(), "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), ";", 2)
+		if len(fields) < 2 {
+			continue
+		}
+		for _, tag := range strings.Split(fields[1], ",") {
+			if strings.HasPrefix(tag, lvmSnapshotTag) {
+				vols = append(vols, lvmVolume{name: fields[0], snap: tag[len(lvmSnapshotTag):]})
+			}
+		}
+	}
+	return vols, nil
+}
+
+// snapshot returns the volume of a snapshot, empty when there is none.
+func (l *LVM) snapshot(name, snap string) (string, error) {
+	vols, err := l.snapshots(name)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range vols {
+		if v.snap == snap {
+			return v.name, nil
+		}
+	}
+	return "", nil
+}
+
+// activate activates a thin snapshot, which LVM skips by default.
+func (l *LVM) activate(lv string) error {
+	_, err := run("lvchange", nil, nil, "-ay", "-K", l.lv(lv))
+	return err
+}
+
+func (l *LVM) deactivate(lv string) error {
+	_, err := run("lvchange", nil, nil, "-an", l.lv(lv))
+	return err
+}
+
+// withSnapshot mounts a snapshot read-only while fn runs.
+func (l *LVM) withSnapshot(name, snap string, fn func(dir string) error) error {
+	lv, err := l.snapshot(name, snap)
+	if err != nil {
+		return err
+	}
+	if lv == "" {
+		return os.ErrNotExist
+	}
+	if err = l.activate(lv); err != nil {
+		return err
+	}
+	defer l.deactivate(lv)
+	dir := filepath.Join(l.mntDir, lv)
+	if err = l.mount(lv, dir, true); err != nil {
+		return err
+	}
+	defer os.Remove(dir)
+	defer l.umount(dir)
+	return fn(dir)
+}
+
+func (l *LVM) Create(name string) error {
+	_, err := run("lvcreate", nil, nil, "-V", l.size, "-T", l.lv(l.pool), "-n", name)
+	if err != nil {
+		return err
+	}
+	if _, err = run("mkfs."+l.fsType, nil, nil, l.dev(name)); err != nil {
+		return err
+	}
+	return l.mount(name, l.dir(name), false)
+}
+
+func (l *LVM) Destroy(name string) error {
+	vols, err := l.snapshots(name)
+	if err != nil {
+		return err
+	}
+	for _, v := range vols {
+		if _, err = run("lvremove", nil, nil, "-y", l.lv(v.name)); err != nil {
+			return err
+		}
+	}
+	if err = l.umount(l.dir(name)); err != nil {
+		return err
+	}
+	if _, err = run("lvremove", nil, nil, "-y", l.lv(name)); err != nil {
+		return err
+	}
+	return os.Remove(l.dir(name))
+}
+
+// Snapshot takes a thin snapshot. Creating it suspends the volume, which
+// freezes the file system, so the files flushed for export are on disk.
+// A snapshot of the same name is replaced. Snapshots stay inactive, they
+// are activated only while mounted.
+func (l *LVM) Snapshot(name, snap string) error {
+	old, err := l.snapshot(name, snap)
+	if err != nil {
+		return err
+	}
+	lv := name + lvmNameSep + strconv.FormatInt(time.Now().UnixNano(), 36)
+	_, err = run("lvcreate", nil, nil, "-s", "-ky", "-n", lv,
+		"--addtag", lvmDatabaseTag+name, "--addtag", lvmSnapshotTag+snap, l.lv(name))
+	if err != nil || old == "" {
+		return err
+	}
+	_, err = run("lvremove", nil, nil, "-y", l.lv(old))
+	return err
+}
+
+// ListSnap returns the snapshots of a database, oldest first, and an
+// error satisfying os.IsNotExist when there is no such database.
+func (l *LVM) ListSnap(name string) ([]string, error) {
+	if _, err := os.Stat(l.dev(name)); err != nil {
+		return nil, err
+	}
+	vols, err := l.snapshots(name)
+	if err != nil {
+		return nil, err
+	}
+	snaps := make([]string, 0, len(vols))
+	for _, v := range vols {
+		snaps = append(snaps, v.snap)
+	}
+	return snaps, nil
+}
+
+func (l *LVM) Send(name, snap string, w io.Writer) error {
+	return l.withSnapshot(name, snap, func(dir string) error {
+		return sendDir(dir, snap, w)
+	})
+}
+
+func (l *LVM) SendDiff(name, snap0, snap1 string, w io.Writer) error {
+	return l.withSnapshot(name, snap0, func(dir0 string) error {
+		return l.withSnapshot(name, snap1, func(dir1 string) error {
+			return sendDirDiff(dir0, dir1, snap0, snap1, w)
+		})
+	})
+}
+
+// Recv writes a backup stream to a new volume, a snapshot of the parent
+// for an incremental backup, snapshots it and makes it the database
+// volume.
+func (l *LVM) Recv(name string, r io.Reader) (string, error) {
+	tr := tar.NewReader(r)
+	backup, err := readBackupHeader(tr)
+	if err != nil {
+		return "", err
+	}
+	lv := name + lvmRecvSuffix
+	if backup.Parent != "" {
+		var parent string
+		if parent, err = l.snapshot(name, backup.Parent); err != nil {
+			return "", err
+		}
+		if parent == "" {
+			return "", NoParentErr
+		}
+		if _, err = run("lvcreate", nil, nil, "-s", "-kn", "-n", lv, l.lv(parent)); err != nil {
+			return "", err
+		}
+		err = l.activate(lv)
+	} else {
+		_, err = run("lvcreate", nil, nil, "-V", l.size, "-T", l.lv(l.pool), "-n", lv)
+		if err == nil {
+			_, err = run("mkfs."+l.fsType, nil, nil, l.dev(lv))
+		}
+	}
+	if err == nil {
+		err = l.fill(lv, tr, backup)
+	}
+	if err == nil {
+		err = l.swap(name, lv)
+	}
+	if err != nil {
+		run("lvremove", nil, nil, "-y", l.lv(lv))
+		return "", err
+	}
+	return backup.Snapshot, l.Snapshot(name, backup.Snapshot)
+}
+
+// fill writes the files of a backup stream to a volume.
+func (l *LVM) fill(lv string, tr *tar.Reader, backup *fileBackup) error {
+	dir, err := ioutil.TempDir(l.mntDir, lv)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(dir)
+	if err = l.mount(lv, dir, false); err != nil {
+		return err
+	}
+	err = applyBackup(tr, backup, dir)
+	if uerr := l.umount(dir); err == nil {
+		err = uerr
+	}
+	return err
+}
+
+// swap replaces the database volume with lv and mounts it. The database
+// volume is renamed aside and removed only once lv is mounted in its
+// place. When a step fails it is renamed back and mounted again, and lv
+// keeps its name.
+func (l *LVM) swap(name, lv string) error {
+	old := name + lvmOldSuffix
+	if err := l.umount(l.dir(name)); err != nil {
+		return err
+	}
+	_, err := run("lvrename", nil, nil, l.vg, name, old)
+	if err != nil {
+		l.mount(name, l.dir(name), false)
+		return err
+	}
+	if _, err = run("lvrename", nil, nil, l.vg, lv, name); err == nil {
+		if err = l.mount(name, l.dir(name), false); err == nil {
+			_, err = run("lvremove", nil, nil, "-y", l.lv(old))
+			return err
+		}
+		run("lvrename", nil, nil, l.vg, name, lv)
+	}
+	if _, rerr := run("lvrename", nil, nil, l.vg, old, name); rerr == nil {
+		l.mount(name, l.dir(name), false)
+	}
+	return err
+}
+
+// Rollback replaces the database volume with a new thin snapshot of
+// snap.
+func (l *LVM) Rollback(name, snap string) error {
+	parent, err := l.snapshot(name, snap)
+	if err != nil {
+		return err
+	}
+	if parent == "" {
+		return os.ErrNotExist
+	}
+	lv := name + lvmRecvSuffix
+	if _, err = run("lvcreate", nil, nil, "-s", "-kn", "-n", lv, l.lv(parent)); err != nil {
+		return err
+	}
+	if err = l.activate(lv); err == nil {
+		err = l.swap(name, lv)
+	}
+	if err != nil {
+		run("lvremove", nil, nil, "-y", l.lv(lv))
+	}
+	return err
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Memory keeps the snapshots of each database in memory and no data, to
+// test Cmd without storage. It records the calls it gets, like "Snapshot
+// db first", and fails those it is told to. Its streams are the headers
+// of file level backups.
+type Memory struct {
+	mu       sync.Mutex
+	volumes  map[string][]string
+	calls    []string
+	failures map[string]error
+}
+
+func NewMemory() *Memory {
+	return &Memory{volumes: make(map[string][]string), failures: make(map[string]error)}
+}
+
+// Fail makes the calls of a method, like "Recv", fail with err.
+func (m *Memory) Fail(method string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures[method] = err
+}
+
+// Calls returns the calls so far.
+func (m *Memory) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+// call records a call and returns the error it is told to fail with. It
+// is called with the lock held.
+func (m *Memory) call(method string, args ...string) error {
+	m.calls = append(m.calls, method+" "+strings.Join(args, " "))
+	return m.failures[method]
+}
+
+func (m *Memory) hasSnap(name, snap string) bool {
+	for _, s := range m.volumes[name] {
+		if s == snap {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Memory) Create(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("Create", name); err != nil {
+		return err
+	}
+	if _, ok := m.volumes[name]; ok {
+		return os.ErrExist
+	}
+	m.volumes[name] = make([]string, 0)
+	return nil
+}
+
+func (m *Memory) Destroy(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("Destroy", name); err != nil {
+		return err
+	}
+	if _, ok := m.volumes[name]; !ok {
+		return os.ErrNotExist
+	}
+	delete(m.volumes, name)
+	return nil
+}
+
+func (m *Memory) Snapshot(name, snap string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("Snapshot", name, snap); err != nil {
+		return err
+	}
+	if _, ok := m.volumes[name]; !ok {
+		return os.ErrNotExist
+	}
+	if m.hasSnap(name, snap) {
+		return os.ErrExist
+	}
+	m.volumes[name] = append(m.volumes[name], snap)
+	return nil
+}
+
+func (m *Memory) ListSnap(name string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("ListSnap", name); err != nil {
+		return nil, err
+	}
+	snaps, ok := m.volumes[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return append([]string(nil), snaps...), nil
+}
+
+func (m *Memory) send(w io.Writer, backup *fileBackup) error {
+	tw := tar.NewWriter(w)
+	if err := writeBackupHeader(tw, backup); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+func (m *Memory) Send(name, snap string, w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("Send", name, snap); err != nil {
+		return err
+	}
+	if !m.hasSnap(name, snap) {
+		return os.ErrNotExist
+	}
+	return m.send(w, &fileBackup{Snapshot: snap})
+}
+
+func (m *Memory) SendDiff(name, snap0, snap1 string, w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("SendDiff", name, snap0, snap1); err != nil {
+		return err
+	}
+	if !m.hasSnap(name, snap0) || !m.hasSnap(name, snap1) {
+		return os.ErrNotExist
+	}
+	return m.send(w, &fileBackup{Snapshot: snap1, Parent: snap0})
+}
+
+func (m *Memory) Recv(name string, r io.Reader) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("Recv", name); err != nil {
+		return "", err
+	}
+	if _, ok := m.volumes[name]; !ok {
+		return "", os.ErrNotExist
+	}
+	backup, err := readBackupHeader(tar.NewReader(r))
+	if err != nil {
+		return "", err
+	}
+	if backup.Parent != "" && !m.hasSnap(name, backup.Parent) {
+		return "", NoParentErr
+	}
+	if !m.hasSnap(name, backup.Snapshot) {
+		m.volumes[name] = append(m.volumes[name], backup.Snapshot)
+	}
+	return backup.Snapshot, nil
+}
+
+func (m *Memory) Rollback(name, snap string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("Rollback", name, snap); err != nil {
+		return err
+	}
+	if !m.hasSnap(name, snap) {
+		return os.ErrNotExist
+	}
+	return nil
+}
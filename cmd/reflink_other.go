@@ -0,0 +1,16 @@
+//go:build !linux
+
+package cmd
+
+import (
+	"errors"
+	"os"
+)
+
+var (
+	NoReflinkErr = errors.New("Reflink copies are not supported.")
+)
+
+func reflink(dst, src *os.File) error {
+	return NoReflinkErr
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	backupHeader = ".backup"
+)
+
+// fileBackup is the first entry of a file level backup stream. Deleted lists the
+// files of the parent an incremental backup removes.
+type fileBackup struct {
+	Snapshot string   `json:"snapshot"`
+	Parent   string   `json:"parent,omitempty"`
+	Deleted  []string `json:"deleted,omitempty"`
+}
+
+// sendDir writes the directory of a snapshot as a tar stream.
+func sendDir(dir, snap string, w io.Writer) error {
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+	tw := tar.NewWriter(w)
+	if err := writeBackupHeader(tw, &fileBackup{Snapshot: snap}); err != nil {
+		return err
+	}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || path == dir {
+			return err
+		}
+		return writeTarEntry(tw, dir, path, info)
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// sendDirDiff writes the files of dir1 that are not in dir0 or changed
+// since, as a tar stream that lists the removed files as well.
+func sendDirDiff(dir0, dir1, snap0, snap1 string, w io.Writer) error {
+	changed := make([]string, 0)
+	err := filepath.Walk(dir1, func(path string, info os.FileInfo, err error) error {
+		if err != nil || path == dir1 {
+			return err
+		}
+		rel, _ := filepath.Rel(dir1, path)
+		old, err := os.Lstat(filepath.Join(dir0, rel))
+		if err != nil || !sameFile(old, info) {
+			changed = append(changed, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	backup := &fileBackup{Snapshot: snap1, Parent: snap0}
+	err = filepath.Walk(dir0, func(path string, info os.FileInfo, err error) error {
+		if err != nil || path == dir0 {
+			return err
+		}
+		rel, _ := filepath.Rel(dir0, path)
+		if _, err = os.Lstat(filepath.Join(dir1, rel)); os.IsNotExist(err) {
+			backup.Deleted = append(backup.Deleted, filepath.ToSlash(rel))
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	tw := tar.NewWriter(w)
+	if err = writeBackupHeader(tw, backup); err != nil {
+		return err
+	}
+	for _, path := range changed {
+		info, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+		if err = writeTarEntry(tw, dir1, path, info); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// readBackupHeader reads the first entry of a backup stream.
+func readBackupHeader(tr *tar.Reader) (*fileBackup, error) {
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != backupHeader {
+		return nil, WrongStreamErr
+	}
+	backup := &fileBackup{}
+	if err = json.NewDecoder(tr).Decode(backup); err != nil || !snapName.MatchString(backup.Snapshot) {
+		return nil, WrongStreamErr
+	}
+	if backup.Parent != "" && !snapName.MatchString(backup.Parent) {
+		return nil, WrongStreamErr
+	}
+	return backup, nil
+}
+
+// applyBackup removes the deleted files of a backup from dir, which
+// holds its parent, and writes the files of the stream.
+func applyBackup(tr *tar.Reader, backup *fileBackup, dir string) error {
+	for _, rel := range backup.Deleted {
+		path, err := extractPath(dir, rel)
+		if err != nil {
+			return err
+		}
+		if err = os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	return extractTar(tr, dir)
+}
+
+func writeBackupHeader(tw *tar.Writer, backup *fileBackup) error {
+	data, err := json.Marshal(backup)
+	if err != nil {
+		return err
+	}
+	hdr := &tar.Header{Name: backupHeader, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}
+	if err = tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+func writeTarEntry(tw *tar.Writer, dir, path string, info os.FileInfo) error {
+	link := ""
+	if info.Mode()&os.ModeSymlink != 0 {
+		s, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		link = s
+	} else if !info.IsDir() && !info.Mode().IsRegular() {
+		return nil
+	}
+	hdr, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	rel, _ := filepath.Rel(dir, path)
+	hdr.Name = filepath.ToSlash(rel)
+	if err = tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(tw, file)
+	return err
+}
+
+// extractPath returns the path of a stream entry in dir, rejecting names
+// that lead out of it, by their elements or through a symbolic link the
+// stream or the parent snapshot put in dir.
+func extractPath(dir, name string) (string, error) {
+	dir = filepath.Clean(dir)
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return "", WrongStreamErr
+	}
+	for p := filepath.Dir(path); len(p) > len(dir); p = filepath.Dir(p) {
+		if info, err := os.Lstat(p); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return "", WrongStreamErr
+		}
+	}
+	return path, nil
+}
+
+// extractTar writes the entries of a stream to dir. Files replace the
+// links to the parent snapshot rather than writing through them.
+func extractTar(tr *tar.Reader, dir string) error {
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		path, err := extractPath(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+		mode := os.FileMode(hdr.Mode).Perm()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(path, mode); err != nil {
+				return err
+			}
+			continue
+		case tar.TypeSymlink:
+			os.Remove(path)
+			if err = os.Symlink(hdr.Linkname, path); err != nil {
+				return err
+			}
+			continue
+		case tar.TypeReg:
+		default:
+			return WrongStreamErr
+		}
+		os.Remove(path)
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(file, tr)
+		if cerr := file.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+		if err = os.Chtimes(path, hdr.ModTime, hdr.ModTime); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"github.com/freepk/zfs"
+	"io"
+	"strings"
+)
+
+// ZFS keeps the databases in the datasets of a parent file system.
+type ZFS struct {
+	fileSys string
+}
+
+func NewZFS(fileSys string) *ZFS {
+	return &ZFS{fileSys: fileSys}
+}
+
+func (z *ZFS) dataset(name string) string {
+	return z.fileSys + "/" + name
+}
+
+func (z *ZFS) snapshot(name, snap string) string {
+	return z.dataset(name) + "@" + snap
+}
+
+func (z *ZFS) Create(name string) error {
+	return zfs.Create(z.dataset(name))
+}
+
+func (z *ZFS) Destroy(name string) error {
+	return zfs.Destroy(z.dataset(name), true, false)
+}
+
+func (z *ZFS) Snapshot(name, snap string) error {
+	return zfs.Snapshot(z.snapshot(name, snap))
+}
+
+func (z *ZFS) ListSnap(name string) ([]string, error) {
+	return zfs.ListSnap(z.dataset(name))
+}
+
+func (z *ZFS) Send(name, snap string, w io.Writer) error {
+	return zfs.Send(z.snapshot(name, snap), w)
+}
+
+func (z *ZFS) SendDiff(name, snap0, snap1 string, w io.Writer) error {
+	return zfs.SendDiff(z.snapshot(name, snap0), z.snapshot(name, snap1), false, w)
+}
+
+func (z *ZFS) Recv(name string, r io.Reader) (string, error) {
+	snap, err := zfs.Recv(z.dataset(name), true, r)
+	if err != nil {
+		return "", err
+	}
+	return snap[(strings.LastIndexByte(snap, '@') + 1):], nil
+}
+
+func (z *ZFS) Rollback(name, snap string) error {
+	return zfs.Rollback(z.snapshot(name, snap), true)
+}
@@ -0,0 +1,413 @@
+// Package fakesql is a database/sql driver standing in for a MySQL
+// server in tests. A Server keeps the names of databases and tables,
+// answers SHOW TABLES, records every statement with the connection that
+// ran it and fails the statements it is told to.
+package fakesql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	UnknownServerErr   = errors.New("Unknown fake server.")
+	UnknownDatabaseErr = errors.New("Unknown database.")
+	DatabaseExistsErr  = errors.New("Database exists.")
+	TableExistsErr     = errors.New("Table already exists.")
+	UnknownTableErr    = errors.New("Unknown table.")
+	NoDatabaseErr      = errors.New("No database selected.")
+)
+
+const (
+	// DriverName is the name to open the servers with, their names are
+	// the data source names.
+	DriverName   = "fakesql"
+	emptyString  = ""
+	showTables   = "SHOW TABLES"
+	tablesPrefix = "Tables_in_"
+)
+
+var (
+	servers   = make(map[string]*Server)
+	serversMu sync.Mutex
+)
+
+func init() {
+	sql.Register(DriverName, fakeDriver{})
+}
+
+// Statement is a statement a server ran, or failed, and the number of
+// the connection it ran on.
+type Statement struct {
+	Conn  int
+	Query string
+	Err   error
+}
+
+type failure struct {
+	prefix string
+	err    error
+}
+
+// Server is a fake MySQL server.
+type Server struct {
+	mu         sync.Mutex
+	databases  map[string]map[string]bool
+	statements []Statement
+	failures   []failure
+	conns      int
+	open       int
+	discard    bool
+	dataDir    string
+}
+
+// NewServer returns a server that sql.Open(DriverName, dsn) connects to.
+// A server of the same name is replaced.
+func NewServer(dsn string) *Server {
+	s := &Server{databases: make(map[string]map[string]bool)}
+	serversMu.Lock()
+	servers[dsn] = s
+	serversMu.Unlock()
+	return s
+}
+
+// Fail makes the statements that start with prefix, ignoring case, fail
+// with err. Statements that fail are recorded as well.
+func (s *Server) Fail(prefix string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{prefix: prefix, err: err})
+}
+
+// Statements returns the statements run so far.
+func (s *Server) Statements() []Statement {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Statement(nil), s.statements...)
+}
+
+// Queries returns the text of the statements run so far.
+func (s *Server) Queries() []string {
+	statements := s.Statements()
+	queries := make([]string, len(statements))
+	for i, st := range statements {
+		queries[i] = st.Query
+	}
+	return queries
+}
+
+// MakeDirs makes CREATE DATABASE create the directory of the database in
+// dataDir, as a server does in its data directory.
+func (s *Server) MakeDirs(dataDir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dataDir = dataDir
+}
+
+// DiscardConns makes the connections invalid once a statement ran, so
+// that a pool closes them instead of using them again, as it does with
+// connections a server closed.
+func (s *Server) DiscardConns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.discard = true
+}
+
+// OpenConns returns the number of connections not closed yet.
+func (s *Server) OpenConns() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.open
+}
+
+// AddTable adds a table, and its database when there is none.
+func (s *Server) AddTable(database, table string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.databases[database] == nil {
+		s.databases[database] = make(map[string]bool)
+	}
+	s.databases[database][table] = true
+}
+
+// HasDatabase reports whether the server has a database.
+func (s *Server) HasDatabase(database string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.databases[database] != nil
+}
+
+// Tables returns the tables of a database in the order SHOW TABLES does.
+func (s *Server) Tables(database string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables(database)
+}
+
+func (s *Server) tables(database string) []string {
+	tables := make([]string, 0, len(s.databases[database]))
+	for table := range s.databases[database] {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// run runs a statement on a connection and returns the rows of SHOW
+// TABLES.
+func (s *Server) run(c *conn, query string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query = strings.TrimSpace(query)
+	err := s.failure(query)
+	if err == nil {
+		err = s.exec(c, query)
+	}
+	s.statements = append(s.statements, Statement{Conn: c.id, Query: query, Err: err})
+	if err != nil || !strings.EqualFold(query, showTables) {
+		return nil, err
+	}
+	return s.tables(c.database), nil
+}
+
+func (s *Server) failure(query string) error {
+	for _, f := range s.failures {
+		if len(query) >= len(f.prefix) && strings.EqualFold(query[:len(f.prefix)], f.prefix) {
+			return f.err
+		}
+	}
+	return nil
+}
+
+// exec changes the databases and tables the way a statement does.
+// Statements that change neither are accepted as they are.
+func (s *Server) exec(c *conn, query string) error {
+	words := strings.Fields(query)
+	if len(words) < 2 {
+		return nil
+	}
+	verb := strings.ToUpper(words[0] + " " + words[1])
+	switch {
+	case strings.EqualFold(words[0], "USE"):
+		name := unquote(strings.TrimSpace(query[len(words[0]):]))
+		if s.databases[name] == nil {
+			return UnknownDatabaseErr
+		}
+		c.database = name
+	case strings.EqualFold(query, showTables):
+		if c.database == emptyString {
+			return NoDatabaseErr
+		}
+	case verb == "CREATE DATABASE":
+		name := unquote(rest(query, 2))
+		if s.databases[name] != nil {
+			return DatabaseExistsErr
+		}
+		if s.dataDir != emptyString {
+			if err := os.Mkdir(filepath.Join(s.dataDir, name), 0755); err != nil {
+				return err
+			}
+		}
+		s.databases[name] = make(map[string]bool)
+	case verb == "DROP DATABASE":
+		name := unquote(rest(query, 2))
+		if s.databases[name] == nil {
+			return UnknownDatabaseErr
+		}
+		delete(s.databases, name)
+		if c.database == name {
+			c.database = emptyString
+		}
+	case verb == "CREATE TABLE":
+		database, table := s.tableName(c, identifier(rest(query, 2)))
+		if s.databases[database] == nil {
+			return NoDatabaseErr
+		}
+		if s.databases[database][table] {
+			return TableExistsErr
+		}
+		s.databases[database][table] = true
+	case verb == "DROP TABLE":
+		names := strings.Split(rest(query, 2), ",")
+		for _, name := range names {
+			database, table := s.tableName(c, identifier(name))
+			if !s.databases[database][table] {
+				return UnknownTableErr
+			}
+		}
+		for _, name := range names {
+			database, table := s.tableName(c, identifier(name))
+			delete(s.databases[database], table)
+		}
+	}
+	return nil
+}
+
+// tableName splits a name that may be qualified by its database.
+func (s *Server) tableName(c *conn, name string) (string, string) {
+	if i := strings.Index(name, "`.`"); i >= 0 {
+		return unquote(name[:(i + 1)]), unquote(name[(i + 2):])
+	}
+	return c.database, unquote(name)
+}
+
+// rest returns the text of a statement after its first n words.
+func rest(query string, n int) string {
+	for i := 0; i < n; i++ {
+		query = strings.TrimSpace(query)
+		if j := strings.IndexAny(query, " \t\n"); j >= 0 {
+			query = query[j:]
+		} else {
+			query = emptyString
+		}
+	}
+	return strings.TrimSpace(query)
+}
+
+// identifier returns the identifier a text starts with, quoted or not,
+// with its database.
+func identifier(s string) string {
+	s = strings.TrimSpace(s)
+	i := 0
+	for i < len(s) {
+		if s[i] == '`' {
+			j := strings.IndexByte(s[(i+1):], '`')
+			if j < 0 {
+				return s
+			}
+			i += j + 2
+			continue
+		}
+		if s[i] == ' ' || s[i] == '(' || s[i] == ',' {
+			break
+		}
+		i++
+	}
+	return s[:i]
+}
+
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '`' && s[len(s)-1] == '`' {
+		return strings.Replace(s[1:(len(s)-1)], "``", "`", -1)
+	}
+	return s
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	serversMu.Lock()
+	s := servers[dsn]
+	serversMu.Unlock()
+	if s == nil {
+		return nil, UnknownServerErr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conns++
+	s.open++
+	return &conn{server: s, id: s.conns}, nil
+}
+
+type conn struct {
+	server   *Server
+	id       int
+	database string
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) IsValid() bool {
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+	return !c.server.discard
+}
+
+func (c *conn) Close() error {
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+	c.server.open--
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *conn) Commit() error {
+	return nil
+}
+
+func (c *conn) Rollback() error {
+	return nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if _, err := c.server.run(c, query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	tables, err := c.server.run(c, query)
+	if err != nil {
+		return nil, err
+	}
+	return &rows{column: tablesPrefix + c.database, values: tables}, nil
+}
+
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), s.query, nil)
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.QueryContext(context.Background(), s.query, nil)
+}
+
+type rows struct {
+	column string
+	values []string
+	pos    int
+}
+
+func (r *rows) Columns() []string {
+	return []string{r.column}
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+	dest[0] = r.values[r.pos]
+	r.pos++
+	return nil
+}
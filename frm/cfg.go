@@ -0,0 +1,106 @@
+package frm
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+)
+
+var (
+	WrongCFGFileErr       = errors.New("Wrong CFG file.")
+	UnsupportedCFGFileErr = errors.New("Unsupported CFG file version.")
+)
+
+const (
+	cfgVersion1   = 1
+	cfgColumnSize = 32
+	sysDictType   = 8
+	cfgExt        = "cfg"
+	ibdExt        = "ibd"
+)
+
+// cfgTable is the metadata FLUSH TABLES ... FOR EXPORT writes to a .cfg
+// file of MySQL 5.6 and 5.7.
+type cfgTable struct {
+	hostname string
+	name     string
+	autoInc  uint64
+	pageSize uint32
+	flags    uint32
+	columns  []dictColumn
+}
+
+type cfgReader struct {
+	data []byte
+	err  error
+}
+
+func (r *cfgReader) next(n int) []byte {
+	if r.err != nil || n < 0 || n > len(r.data) {
+		r.err = WrongCFGFileErr
+		return make([]byte, n)
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *cfgReader) uint32() uint32 {
+	return binary.BigEndian.Uint32(r.next(4))
+}
+
+// string reads a length prefixed string, the length counts the
+// terminating zero byte.
+func (r *cfgReader) string() string {
+	n := int(r.uint32())
+	b := r.next(n)
+	if n > 0 {
+		b = b[:(n - 1)]
+	}
+	return string(b)
+}
+
+func readCfg(path string) (*cfgTable, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &cfgReader{data: data}
+	if r.uint32() != cfgVersion1 {
+		if r.err != nil {
+			return nil, r.err
+		}
+		return nil, UnsupportedCFGFileErr
+	}
+	t := &cfgTable{}
+	t.hostname = r.string()
+	t.name = r.string()
+	t.autoInc = binary.BigEndian.Uint64(r.next(8))
+	t.pageSize = r.uint32()
+	t.flags = r.uint32()
+	n := int(r.uint32())
+	if r.err != nil || n*cfgColumnSize > len(r.data) {
+		return nil, WrongCFGFileErr
+	}
+	t.columns = make([]dictColumn, 0, n)
+	for i := 0; i < n; i++ {
+		d := r.next(cfgColumnSize)
+		dc := dictColumn{
+			prtype: binary.BigEndian.Uint32(d[0:4]),
+			mtype:  binary.BigEndian.Uint32(d[4:8]),
+			length: binary.BigEndian.Uint32(d[8:12]),
+		}
+		// skip mbminmaxlen, ind, ord_part and max_prefix
+		b := r.next(int(binary.BigEndian.Uint32(d[28:32])))
+		if len(b) > 0 {
+			dc.name = string(b[:(len(b) - 1)])
+		}
+		if dc.mtype != sysDictType {
+			t.columns = append(t.columns, dc)
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return t, nil
+}
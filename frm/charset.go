@@ -1,236 +1,434 @@
 package frm
 
-type charset struct {
-	id        int
-	name      string
-	collate   string
-	maxLen    int
-	isDefault bool
+import (
+	"errors"
+	"golang.org/x/text/encoding"
+	"sort"
+	"strings"
+)
+
+var (
+	UnknownCharsetErr = errors.New("Unknown charset.")
+)
+
+// Charset is a collation of a character set as listed by SHOW COLLATION.
+// IsDefault marks the primary collation of the character set, Encoding
+// converts its values from and to UTF-8 and is nil for binary, armscii8,
+// dec8, geostd8, hp8, keybcs2, macce and swe7.
+type Charset struct {
+	Id        int
+	Name      string
+	Collate   string
+	MaxLen    int
+	IsDefault bool
+	Encoding  encoding.Encoding
 }
 
+const (
+	mySQL80VersionId = 80000
+	nopadIdOffset    = 1024
+	maxNopadBaseId   = 247
+	utf8mb3Prefix    = "utf8mb3"
+)
+
 var (
-	charsets = map[int]*charset{
-		64:  &charset{id: 64, name: "armscii8", collate: "armscii8_bin", maxLen: 1, isDefault: false},
-		32:  &charset{id: 32, name: "armscii8", collate: "armscii8_general_ci", maxLen: 1, isDefault: true},
-		11:  &charset{id: 11, name: "ascii", collate: "ascii_general_ci", maxLen: 1, isDefault: true},
-		65:  &charset{id: 65, name: "ascii", collate: "ascii_bin", maxLen: 1, isDefault: false},
-		84:  &charset{id: 84, name: "big5", collate: "big5_bin", maxLen: 2, isDefault: false},
-		1:   &charset{id: 1, name: "big5", collate: "big5_chinese_ci", maxLen: 2, isDefault: true},
-		63:  &charset{id: 63, name: "binary", collate: "binary", maxLen: 1, isDefault: true},
-		99:  &charset{id: 99, name: "cp1250", collate: "cp1250_polish_ci", maxLen: 1, isDefault: false},
-		66:  &charset{id: 66, name: "cp1250", collate: "cp1250_bin", maxLen: 1, isDefault: false},
-		44:  &charset{id: 44, name: "cp1250", collate: "cp1250_croatian_ci", maxLen: 1, isDefault: false},
-		34:  &charset{id: 34, name: "cp1250", collate: "cp1250_czech_cs", maxLen: 1, isDefault: false},
-		26:  &charset{id: 26, name: "cp1250", collate: "cp1250_general_ci", maxLen: 1, isDefault: true},
-		52:  &charset{id: 52, name: "cp1251", collate: "cp1251_general_cs", maxLen: 1, isDefault: false},
-		51:  &charset{id: 51, name: "cp1251", collate: "cp1251_general_ci", maxLen: 1, isDefault: true},
-		50:  &charset{id: 50, name: "cp1251", collate: "cp1251_bin", maxLen: 1, isDefault: false},
-		23:  &charset{id: 23, name: "cp1251", collate: "cp1251_ukrainian_ci", maxLen: 1, isDefault: false},
-		14:  &charset{id: 14, name: "cp1251", collate: "cp1251_bulgarian_ci", maxLen: 1, isDefault: false},
-		67:  &charset{id: 67, name: "cp1256", collate: "cp1256_bin", maxLen: 1, isDefault: false},
-		57:  &charset{id: 57, name: "cp1256", collate: "cp1256_general_ci", maxLen: 1, isDefault: true},
-		59:  &charset{id: 59, name: "cp1257", collate: "cp1257_general_ci", maxLen: 1, isDefault: true},
-		58:  &charset{id: 58, name: "cp1257", collate: "cp1257_bin", maxLen: 1, isDefault: false},
-		29:  &charset{id: 29, name: "cp1257", collate: "cp1257_lithuanian_ci", maxLen: 1, isDefault: false},
-		80:  &charset{id: 80, name: "cp850", collate: "cp850_bin", maxLen: 1, isDefault: false},
-		4:   &charset{id: 4, name: "cp850", collate: "cp850_general_ci", maxLen: 1, isDefault: true},
-		81:  &charset{id: 81, name: "cp852", collate: "cp852_bin", maxLen: 1, isDefault: false},
-		40:  &charset{id: 40, name: "cp852", collate: "cp852_general_ci", maxLen: 1, isDefault: true},
-		68:  &charset{id: 68, name: "cp866", collate: "cp866_bin", maxLen: 1, isDefault: false},
-		36:  &charset{id: 36, name: "cp866", collate: "cp866_general_ci", maxLen: 1, isDefault: true},
-		96:  &charset{id: 96, name: "cp932", collate: "cp932_bin", maxLen: 2, isDefault: false},
-		95:  &charset{id: 95, name: "cp932", collate: "cp932_japanese_ci", maxLen: 2, isDefault: true},
-		69:  &charset{id: 69, name: "dec8", collate: "dec8_bin", maxLen: 1, isDefault: false},
-		3:   &charset{id: 3, name: "dec8", collate: "dec8_swedish_ci", maxLen: 1, isDefault: true},
-		97:  &charset{id: 97, name: "eucjpms", collate: "eucjpms_japanese_ci", maxLen: 3, isDefault: true},
-		98:  &charset{id: 98, name: "eucjpms", collate: "eucjpms_bin", maxLen: 3, isDefault: false},
-		85:  &charset{id: 85, name: "euckr", collate: "euckr_bin", maxLen: 2, isDefault: false},
-		19:  &charset{id: 19, name: "euckr", collate: "euckr_korean_ci", maxLen: 2, isDefault: true},
-		250: &charset{id: 250, name: "gb18030", collate: "gb18030_unicode_520_ci", maxLen: 4, isDefault: false},
-		249: &charset{id: 249, name: "gb18030", collate: "gb18030_bin", maxLen: 4, isDefault: false},
-		248: &charset{id: 248, name: "gb18030", collate: "gb18030_chinese_ci", maxLen: 4, isDefault: true},
-		86:  &charset{id: 86, name: "gb2312", collate: "gb2312_bin", maxLen: 2, isDefault: false},
-		24:  &charset{id: 24, name: "gb2312", collate: "gb2312_chinese_ci", maxLen: 2, isDefault: true},
-		87:  &charset{id: 87, name: "gbk", collate: "gbk_bin", maxLen: 2, isDefault: false},
-		28:  &charset{id: 28, name: "gbk", collate: "gbk_chinese_ci", maxLen: 2, isDefault: true},
-		93:  &charset{id: 93, name: "geostd8", collate: "geostd8_bin", maxLen: 1, isDefault: false},
-		92:  &charset{id: 92, name: "geostd8", collate: "geostd8_general_ci", maxLen: 1, isDefault: true},
-		25:  &charset{id: 25, name: "greek", collate: "greek_general_ci", maxLen: 1, isDefault: true},
-		70:  &charset{id: 70, name: "greek", collate: "greek_bin", maxLen: 1, isDefault: false},
-		71:  &charset{id: 71, name: "hebrew", collate: "hebrew_bin", maxLen: 1, isDefault: false},
-		16:  &charset{id: 16, name: "hebrew", collate: "hebrew_general_ci", maxLen: 1, isDefault: true},
-		72:  &charset{id: 72, name: "hp8", collate: "hp8_bin", maxLen: 1, isDefault: false},
-		6:   &charset{id: 6, name: "hp8", collate: "hp8_english_ci", maxLen: 1, isDefault: true},
-		73:  &charset{id: 73, name: "keybcs2", collate: "keybcs2_bin", maxLen: 1, isDefault: false},
-		37:  &charset{id: 37, name: "keybcs2", collate: "keybcs2_general_ci", maxLen: 1, isDefault: true},
-		74:  &charset{id: 74, name: "koi8r", collate: "koi8r_bin", maxLen: 1, isDefault: false},
-		7:   &charset{id: 7, name: "koi8r", collate: "koi8r_general_ci", maxLen: 1, isDefault: true},
-		75:  &charset{id: 75, name: "koi8u", collate: "koi8u_bin", maxLen: 1, isDefault: false},
-		22:  &charset{id: 22, name: "koi8u", collate: "koi8u_general_ci", maxLen: 1, isDefault: true},
-		94:  &charset{id: 94, name: "latin1", collate: "latin1_spanish_ci", maxLen: 1, isDefault: false},
-		49:  &charset{id: 49, name: "latin1", collate: "latin1_general_cs", maxLen: 1, isDefault: false},
-		48:  &charset{id: 48, name: "latin1", collate: "latin1_general_ci", maxLen: 1, isDefault: false},
-		47:  &charset{id: 47, name: "latin1", collate: "latin1_bin", maxLen: 1, isDefault: false},
-		31:  &charset{id: 31, name: "latin1", collate: "latin1_german2_ci", maxLen: 1, isDefault: false},
-		15:  &charset{id: 15, name: "latin1", collate: "latin1_danish_ci", maxLen: 1, isDefault: false},
-		8:   &charset{id: 8, name: "latin1", collate: "latin1_swedish_ci", maxLen: 1, isDefault: true},
-		5:   &charset{id: 5, name: "latin1", collate: "latin1_german1_ci", maxLen: 1, isDefault: false},
-		77:  &charset{id: 77, name: "latin2", collate: "latin2_bin", maxLen: 1, isDefault: false},
-		27:  &charset{id: 27, name: "latin2", collate: "latin2_croatian_ci", maxLen: 1, isDefault: false},
-		21:  &charset{id: 21, name: "latin2", collate: "latin2_hungarian_ci", maxLen: 1, isDefault: false},
-		9:   &charset{id: 9, name: "latin2", collate: "latin2_general_ci", maxLen: 1, isDefault: true},
-		2:   &charset{id: 2, name: "latin2", collate: "latin2_czech_cs", maxLen: 1, isDefault: false},
-		78:  &charset{id: 78, name: "latin5", collate: "latin5_bin", maxLen: 1, isDefault: false},
-		30:  &charset{id: 30, name: "latin5", collate: "latin5_turkish_ci", maxLen: 1, isDefault: true},
-		79:  &charset{id: 79, name: "latin7", collate: "latin7_bin", maxLen: 1, isDefault: false},
-		42:  &charset{id: 42, name: "latin7", collate: "latin7_general_cs", maxLen: 1, isDefault: false},
-		41:  &charset{id: 41, name: "latin7", collate: "latin7_general_ci", maxLen: 1, isDefault: true},
-		20:  &charset{id: 20, name: "latin7", collate: "latin7_estonian_cs", maxLen: 1, isDefault: false},
-		43:  &charset{id: 43, name: "macce", collate: "macce_bin", maxLen: 1, isDefault: false},
-		38:  &charset{id: 38, name: "macce", collate: "macce_general_ci", maxLen: 1, isDefault: true},
-		53:  &charset{id: 53, name: "macroman", collate: "macroman_bin", maxLen: 1, isDefault: false},
-		39:  &charset{id: 39, name: "macroman", collate: "macroman_general_ci", maxLen: 1, isDefault: true},
-		88:  &charset{id: 88, name: "sjis", collate: "sjis_bin", maxLen: 2, isDefault: false},
-		13:  &charset{id: 13, name: "sjis", collate: "sjis_japanese_ci", maxLen: 2, isDefault: true},
-		82:  &charset{id: 82, name: "swe7", collate: "swe7_bin", maxLen: 1, isDefault: false},
-		10:  &charset{id: 10, name: "swe7", collate: "swe7_swedish_ci", maxLen: 1, isDefault: true},
-		18:  &charset{id: 18, name: "tis620", collate: "tis620_thai_ci", maxLen: 1, isDefault: true},
-		89:  &charset{id: 89, name: "tis620", collate: "tis620_bin", maxLen: 1, isDefault: false},
-		35:  &charset{id: 35, name: "ucs2", collate: "ucs2_general_ci", maxLen: 2, isDefault: true},
-		134: &charset{id: 134, name: "ucs2", collate: "ucs2_estonian_ci", maxLen: 2, isDefault: false},
-		142: &charset{id: 142, name: "ucs2", collate: "ucs2_spanish2_ci", maxLen: 2, isDefault: false},
-		150: &charset{id: 150, name: "ucs2", collate: "ucs2_unicode_520_ci", maxLen: 2, isDefault: false},
-		133: &charset{id: 133, name: "ucs2", collate: "ucs2_polish_ci", maxLen: 2, isDefault: false},
-		141: &charset{id: 141, name: "ucs2", collate: "ucs2_slovak_ci", maxLen: 2, isDefault: false},
-		149: &charset{id: 149, name: "ucs2", collate: "ucs2_croatian_ci", maxLen: 2, isDefault: false},
-		132: &charset{id: 132, name: "ucs2", collate: "ucs2_slovenian_ci", maxLen: 2, isDefault: false},
-		140: &charset{id: 140, name: "ucs2", collate: "ucs2_lithuanian_ci", maxLen: 2, isDefault: false},
-		148: &charset{id: 148, name: "ucs2", collate: "ucs2_german2_ci", maxLen: 2, isDefault: false},
-		131: &charset{id: 131, name: "ucs2", collate: "ucs2_romanian_ci", maxLen: 2, isDefault: false},
-		139: &charset{id: 139, name: "ucs2", collate: "ucs2_danish_ci", maxLen: 2, isDefault: false},
-		147: &charset{id: 147, name: "ucs2", collate: "ucs2_sinhala_ci", maxLen: 2, isDefault: false},
-		130: &charset{id: 130, name: "ucs2", collate: "ucs2_latvian_ci", maxLen: 2, isDefault: false},
-		138: &charset{id: 138, name: "ucs2", collate: "ucs2_czech_ci", maxLen: 2, isDefault: false},
-		146: &charset{id: 146, name: "ucs2", collate: "ucs2_hungarian_ci", maxLen: 2, isDefault: false},
-		129: &charset{id: 129, name: "ucs2", collate: "ucs2_icelandic_ci", maxLen: 2, isDefault: false},
-		137: &charset{id: 137, name: "ucs2", collate: "ucs2_turkish_ci", maxLen: 2, isDefault: false},
-		145: &charset{id: 145, name: "ucs2", collate: "ucs2_esperanto_ci", maxLen: 2, isDefault: false},
-		128: &charset{id: 128, name: "ucs2", collate: "ucs2_unicode_ci", maxLen: 2, isDefault: false},
-		136: &charset{id: 136, name: "ucs2", collate: "ucs2_swedish_ci", maxLen: 2, isDefault: false},
-		144: &charset{id: 144, name: "ucs2", collate: "ucs2_persian_ci", maxLen: 2, isDefault: false},
-		159: &charset{id: 159, name: "ucs2", collate: "ucs2_general_mysql500_ci", maxLen: 2, isDefault: false},
-		90:  &charset{id: 90, name: "ucs2", collate: "ucs2_bin", maxLen: 2, isDefault: false},
-		135: &charset{id: 135, name: "ucs2", collate: "ucs2_spanish_ci", maxLen: 2, isDefault: false},
-		143: &charset{id: 143, name: "ucs2", collate: "ucs2_roman_ci", maxLen: 2, isDefault: false},
-		151: &charset{id: 151, name: "ucs2", collate: "ucs2_vietnamese_ci", maxLen: 2, isDefault: false},
-		91:  &charset{id: 91, name: "ujis", collate: "ujis_bin", maxLen: 3, isDefault: false},
-		12:  &charset{id: 12, name: "ujis", collate: "ujis_japanese_ci", maxLen: 3, isDefault: true},
-		54:  &charset{id: 54, name: "utf16", collate: "utf16_general_ci", maxLen: 4, isDefault: true},
-		107: &charset{id: 107, name: "utf16", collate: "utf16_estonian_ci", maxLen: 4, isDefault: false},
-		115: &charset{id: 115, name: "utf16", collate: "utf16_spanish2_ci", maxLen: 4, isDefault: false},
-		123: &charset{id: 123, name: "utf16", collate: "utf16_unicode_520_ci", maxLen: 4, isDefault: false},
-		106: &charset{id: 106, name: "utf16", collate: "utf16_polish_ci", maxLen: 4, isDefault: false},
-		114: &charset{id: 114, name: "utf16", collate: "utf16_slovak_ci", maxLen: 4, isDefault: false},
-		122: &charset{id: 122, name: "utf16", collate: "utf16_croatian_ci", maxLen: 4, isDefault: false},
-		105: &charset{id: 105, name: "utf16", collate: "utf16_slovenian_ci", maxLen: 4, isDefault: false},
-		113: &charset{id: 113, name: "utf16", collate: "utf16_lithuanian_ci", maxLen: 4, isDefault: false},
-		121: &charset{id: 121, name: "utf16", collate: "utf16_german2_ci", maxLen: 4, isDefault: false},
-		104: &charset{id: 104, name: "utf16", collate: "utf16_romanian_ci", maxLen: 4, isDefault: false},
-		112: &charset{id: 112, name: "utf16", collate: "utf16_danish_ci", maxLen: 4, isDefault: false},
-		120: &charset{id: 120, name: "utf16", collate: "utf16_sinhala_ci", maxLen: 4, isDefault: false},
-		103: &charset{id: 103, name: "utf16", collate: "utf16_latvian_ci", maxLen: 4, isDefault: false},
-		111: &charset{id: 111, name: "utf16", collate: "utf16_czech_ci", maxLen: 4, isDefault: false},
-		119: &charset{id: 119, name: "utf16", collate: "utf16_hungarian_ci", maxLen: 4, isDefault: false},
-		102: &charset{id: 102, name: "utf16", collate: "utf16_icelandic_ci", maxLen: 4, isDefault: false},
-		110: &charset{id: 110, name: "utf16", collate: "utf16_turkish_ci", maxLen: 4, isDefault: false},
-		118: &charset{id: 118, name: "utf16", collate: "utf16_esperanto_ci", maxLen: 4, isDefault: false},
-		101: &charset{id: 101, name: "utf16", collate: "utf16_unicode_ci", maxLen: 4, isDefault: false},
-		109: &charset{id: 109, name: "utf16", collate: "utf16_swedish_ci", maxLen: 4, isDefault: false},
-		117: &charset{id: 117, name: "utf16", collate: "utf16_persian_ci", maxLen: 4, isDefault: false},
-		55:  &charset{id: 55, name: "utf16", collate: "utf16_bin", maxLen: 4, isDefault: false},
-		108: &charset{id: 108, name: "utf16", collate: "utf16_spanish_ci", maxLen: 4, isDefault: false},
-		116: &charset{id: 116, name: "utf16", collate: "utf16_roman_ci", maxLen: 4, isDefault: false},
-		124: &charset{id: 124, name: "utf16", collate: "utf16_vietnamese_ci", maxLen: 4, isDefault: false},
-		62:  &charset{id: 62, name: "utf16le", collate: "utf16le_bin", maxLen: 4, isDefault: false},
-		56:  &charset{id: 56, name: "utf16le", collate: "utf16le_general_ci", maxLen: 4, isDefault: true},
-		165: &charset{id: 165, name: "utf32", collate: "utf32_polish_ci", maxLen: 4, isDefault: false},
-		173: &charset{id: 173, name: "utf32", collate: "utf32_slovak_ci", maxLen: 4, isDefault: false},
-		181: &charset{id: 181, name: "utf32", collate: "utf32_croatian_ci", maxLen: 4, isDefault: false},
-		164: &charset{id: 164, name: "utf32", collate: "utf32_slovenian_ci", maxLen: 4, isDefault: false},
-		172: &charset{id: 172, name: "utf32", collate: "utf32_lithuanian_ci", maxLen: 4, isDefault: false},
-		180: &charset{id: 180, name: "utf32", collate: "utf32_german2_ci", maxLen: 4, isDefault: false},
-		163: &charset{id: 163, name: "utf32", collate: "utf32_romanian_ci", maxLen: 4, isDefault: false},
-		171: &charset{id: 171, name: "utf32", collate: "utf32_danish_ci", maxLen: 4, isDefault: false},
-		179: &charset{id: 179, name: "utf32", collate: "utf32_sinhala_ci", maxLen: 4, isDefault: false},
-		162: &charset{id: 162, name: "utf32", collate: "utf32_latvian_ci", maxLen: 4, isDefault: false},
-		170: &charset{id: 170, name: "utf32", collate: "utf32_czech_ci", maxLen: 4, isDefault: false},
-		178: &charset{id: 178, name: "utf32", collate: "utf32_hungarian_ci", maxLen: 4, isDefault: false},
-		161: &charset{id: 161, name: "utf32", collate: "utf32_icelandic_ci", maxLen: 4, isDefault: false},
-		169: &charset{id: 169, name: "utf32", collate: "utf32_turkish_ci", maxLen: 4, isDefault: false},
-		177: &charset{id: 177, name: "utf32", collate: "utf32_esperanto_ci", maxLen: 4, isDefault: false},
-		160: &charset{id: 160, name: "utf32", collate: "utf32_unicode_ci", maxLen: 4, isDefault: false},
-		168: &charset{id: 168, name: "utf32", collate: "utf32_swedish_ci", maxLen: 4, isDefault: false},
-		176: &charset{id: 176, name: "utf32", collate: "utf32_persian_ci", maxLen: 4, isDefault: false},
-		61:  &charset{id: 61, name: "utf32", collate: "utf32_bin", maxLen: 4, isDefault: false},
-		167: &charset{id: 167, name: "utf32", collate: "utf32_spanish_ci", maxLen: 4, isDefault: false},
-		175: &charset{id: 175, name: "utf32", collate: "utf32_roman_ci", maxLen: 4, isDefault: false},
-		183: &charset{id: 183, name: "utf32", collate: "utf32_vietnamese_ci", maxLen: 4, isDefault: false},
-		60:  &charset{id: 60, name: "utf32", collate: "utf32_general_ci", maxLen: 4, isDefault: true},
-		166: &charset{id: 166, name: "utf32", collate: "utf32_estonian_ci", maxLen: 4, isDefault: false},
-		174: &charset{id: 174, name: "utf32", collate: "utf32_spanish2_ci", maxLen: 4, isDefault: false},
-		182: &charset{id: 182, name: "utf32", collate: "utf32_unicode_520_ci", maxLen: 4, isDefault: false},
-		193: &charset{id: 193, name: "utf8", collate: "utf8_icelandic_ci", maxLen: 3, isDefault: false},
-		201: &charset{id: 201, name: "utf8", collate: "utf8_turkish_ci", maxLen: 3, isDefault: false},
-		209: &charset{id: 209, name: "utf8", collate: "utf8_esperanto_ci", maxLen: 3, isDefault: false},
-		192: &charset{id: 192, name: "utf8", collate: "utf8_unicode_ci", maxLen: 3, isDefault: false},
-		200: &charset{id: 200, name: "utf8", collate: "utf8_swedish_ci", maxLen: 3, isDefault: false},
-		208: &charset{id: 208, name: "utf8", collate: "utf8_persian_ci", maxLen: 3, isDefault: false},
-		223: &charset{id: 223, name: "utf8", collate: "utf8_general_mysql500_ci", maxLen: 3, isDefault: false},
-		83:  &charset{id: 83, name: "utf8", collate: "utf8_bin", maxLen: 3, isDefault: false},
-		199: &charset{id: 199, name: "utf8", collate: "utf8_spanish_ci", maxLen: 3, isDefault: false},
-		207: &charset{id: 207, name: "utf8", collate: "utf8_roman_ci", maxLen: 3, isDefault: false},
-		215: &charset{id: 215, name: "utf8", collate: "utf8_vietnamese_ci", maxLen: 3, isDefault: false},
-		198: &charset{id: 198, name: "utf8", collate: "utf8_estonian_ci", maxLen: 3, isDefault: false},
-		206: &charset{id: 206, name: "utf8", collate: "utf8_spanish2_ci", maxLen: 3, isDefault: false},
-		214: &charset{id: 214, name: "utf8", collate: "utf8_unicode_520_ci", maxLen: 3, isDefault: false},
-		33:  &charset{id: 33, name: "utf8", collate: "utf8_general_ci", maxLen: 3, isDefault: true},
-		197: &charset{id: 197, name: "utf8", collate: "utf8_polish_ci", maxLen: 3, isDefault: false},
-		205: &charset{id: 205, name: "utf8", collate: "utf8_slovak_ci", maxLen: 3, isDefault: false},
-		213: &charset{id: 213, name: "utf8", collate: "utf8_croatian_ci", maxLen: 3, isDefault: false},
-		196: &charset{id: 196, name: "utf8", collate: "utf8_slovenian_ci", maxLen: 3, isDefault: false},
-		204: &charset{id: 204, name: "utf8", collate: "utf8_lithuanian_ci", maxLen: 3, isDefault: false},
-		212: &charset{id: 212, name: "utf8", collate: "utf8_german2_ci", maxLen: 3, isDefault: false},
-		195: &charset{id: 195, name: "utf8", collate: "utf8_romanian_ci", maxLen: 3, isDefault: false},
-		203: &charset{id: 203, name: "utf8", collate: "utf8_danish_ci", maxLen: 3, isDefault: false},
-		211: &charset{id: 211, name: "utf8", collate: "utf8_sinhala_ci", maxLen: 3, isDefault: false},
-		194: &charset{id: 194, name: "utf8", collate: "utf8_latvian_ci", maxLen: 3, isDefault: false},
-		202: &charset{id: 202, name: "utf8", collate: "utf8_czech_ci", maxLen: 3, isDefault: false},
-		210: &charset{id: 210, name: "utf8", collate: "utf8_hungarian_ci", maxLen: 3, isDefault: false},
-		229: &charset{id: 229, name: "utf8mb4", collate: "utf8mb4_polish_ci", maxLen: 4, isDefault: false},
-		237: &charset{id: 237, name: "utf8mb4", collate: "utf8mb4_slovak_ci", maxLen: 4, isDefault: false},
-		245: &charset{id: 245, name: "utf8mb4", collate: "utf8mb4_croatian_ci", maxLen: 4, isDefault: false},
-		228: &charset{id: 228, name: "utf8mb4", collate: "utf8mb4_slovenian_ci", maxLen: 4, isDefault: false},
-		236: &charset{id: 236, name: "utf8mb4", collate: "utf8mb4_lithuanian_ci", maxLen: 4, isDefault: false},
-		244: &charset{id: 244, name: "utf8mb4", collate: "utf8mb4_german2_ci", maxLen: 4, isDefault: false},
-		227: &charset{id: 227, name: "utf8mb4", collate: "utf8mb4_romanian_ci", maxLen: 4, isDefault: false},
-		235: &charset{id: 235, name: "utf8mb4", collate: "utf8mb4_danish_ci", maxLen: 4, isDefault: false},
-		243: &charset{id: 243, name: "utf8mb4", collate: "utf8mb4_sinhala_ci", maxLen: 4, isDefault: false},
-		226: &charset{id: 226, name: "utf8mb4", collate: "utf8mb4_latvian_ci", maxLen: 4, isDefault: false},
-		234: &charset{id: 234, name: "utf8mb4", collate: "utf8mb4_czech_ci", maxLen: 4, isDefault: false},
-		242: &charset{id: 242, name: "utf8mb4", collate: "utf8mb4_hungarian_ci", maxLen: 4, isDefault: false},
-		225: &charset{id: 225, name: "utf8mb4", collate: "utf8mb4_icelandic_ci", maxLen: 4, isDefault: false},
-		233: &charset{id: 233, name: "utf8mb4", collate: "utf8mb4_turkish_ci", maxLen: 4, isDefault: false},
-		241: &charset{id: 241, name: "utf8mb4", collate: "utf8mb4_esperanto_ci", maxLen: 4, isDefault: false},
-		224: &charset{id: 224, name: "utf8mb4", collate: "utf8mb4_unicode_ci", maxLen: 4, isDefault: false},
-		232: &charset{id: 232, name: "utf8mb4", collate: "utf8mb4_swedish_ci", maxLen: 4, isDefault: false},
-		240: &charset{id: 240, name: "utf8mb4", collate: "utf8mb4_persian_ci", maxLen: 4, isDefault: false},
-		46:  &charset{id: 46, name: "utf8mb4", collate: "utf8mb4_bin", maxLen: 4, isDefault: false},
-		231: &charset{id: 231, name: "utf8mb4", collate: "utf8mb4_spanish_ci", maxLen: 4, isDefault: false},
-		239: &charset{id: 239, name: "utf8mb4", collate: "utf8mb4_roman_ci", maxLen: 4, isDefault: false},
-		247: &charset{id: 247, name: "utf8mb4", collate: "utf8mb4_vietnamese_ci", maxLen: 4, isDefault: false},
-		45:  &charset{id: 45, name: "utf8mb4", collate: "utf8mb4_general_ci", maxLen: 4, isDefault: true},
-		230: &charset{id: 230, name: "utf8mb4", collate: "utf8mb4_estonian_ci", maxLen: 4, isDefault: false},
-		238: &charset{id: 238, name: "utf8mb4", collate: "utf8mb4_spanish2_ci", maxLen: 4, isDefault: false},
-		246: &charset{id: 246, name: "utf8mb4", collate: "utf8mb4_unicode_520_ci", maxLen: 4, isDefault: false},
+	// primary collations changed by MySQL 8.0
+	defaultCollates80 = map[string]int{
+		"utf8mb4": 255,
 	}
+	// MariaDB has no NO PAD collations for these character sets
+	mySQLOnlyCharsets = map[string]bool{
+		"gb18030": true,
+	}
+	collates = make(map[string]*Charset)
 )
+
+var (
+	charsets = map[int]*Charset{
+		64:  &Charset{Id: 64, Name: "armscii8", Collate: "armscii8_bin", MaxLen: 1, IsDefault: false},
+		32:  &Charset{Id: 32, Name: "armscii8", Collate: "armscii8_general_ci", MaxLen: 1, IsDefault: true},
+		11:  &Charset{Id: 11, Name: "ascii", Collate: "ascii_general_ci", MaxLen: 1, IsDefault: true},
+		65:  &Charset{Id: 65, Name: "ascii", Collate: "ascii_bin", MaxLen: 1, IsDefault: false},
+		84:  &Charset{Id: 84, Name: "big5", Collate: "big5_bin", MaxLen: 2, IsDefault: false},
+		1:   &Charset{Id: 1, Name: "big5", Collate: "big5_chinese_ci", MaxLen: 2, IsDefault: true},
+		63:  &Charset{Id: 63, Name: "binary", Collate: "binary", MaxLen: 1, IsDefault: true},
+		99:  &Charset{Id: 99, Name: "cp1250", Collate: "cp1250_polish_ci", MaxLen: 1, IsDefault: false},
+		66:  &Charset{Id: 66, Name: "cp1250", Collate: "cp1250_bin", MaxLen: 1, IsDefault: false},
+		44:  &Charset{Id: 44, Name: "cp1250", Collate: "cp1250_croatian_ci", MaxLen: 1, IsDefault: false},
+		34:  &Charset{Id: 34, Name: "cp1250", Collate: "cp1250_czech_cs", MaxLen: 1, IsDefault: false},
+		26:  &Charset{Id: 26, Name: "cp1250", Collate: "cp1250_general_ci", MaxLen: 1, IsDefault: true},
+		52:  &Charset{Id: 52, Name: "cp1251", Collate: "cp1251_general_cs", MaxLen: 1, IsDefault: false},
+		51:  &Charset{Id: 51, Name: "cp1251", Collate: "cp1251_general_ci", MaxLen: 1, IsDefault: true},
+		50:  &Charset{Id: 50, Name: "cp1251", Collate: "cp1251_bin", MaxLen: 1, IsDefault: false},
+		23:  &Charset{Id: 23, Name: "cp1251", Collate: "cp1251_ukrainian_ci", MaxLen: 1, IsDefault: false},
+		14:  &Charset{Id: 14, Name: "cp1251", Collate: "cp1251_bulgarian_ci", MaxLen: 1, IsDefault: false},
+		67:  &Charset{Id: 67, Name: "cp1256", Collate: "cp1256_bin", MaxLen: 1, IsDefault: false},
+		57:  &Charset{Id: 57, Name: "cp1256", Collate: "cp1256_general_ci", MaxLen: 1, IsDefault: true},
+		59:  &Charset{Id: 59, Name: "cp1257", Collate: "cp1257_general_ci", MaxLen: 1, IsDefault: true},
+		58:  &Charset{Id: 58, Name: "cp1257", Collate: "cp1257_bin", MaxLen: 1, IsDefault: false},
+		29:  &Charset{Id: 29, Name: "cp1257", Collate: "cp1257_lithuanian_ci", MaxLen: 1, IsDefault: false},
+		80:  &Charset{Id: 80, Name: "cp850", Collate: "cp850_bin", MaxLen: 1, IsDefault: false},
+		4:   &Charset{Id: 4, Name: "cp850", Collate: "cp850_general_ci", MaxLen: 1, IsDefault: true},
+		81:  &Charset{Id: 81, Name: "cp852", Collate: "cp852_bin", MaxLen: 1, IsDefault: false},
+		40:  &Charset{Id: 40, Name: "cp852", Collate: "cp852_general_ci", MaxLen: 1, IsDefault: true},
+		68:  &Charset{Id: 68, Name: "cp866", Collate: "cp866_bin", MaxLen: 1, IsDefault: false},
+		36:  &Charset{Id: 36, Name: "cp866", Collate: "cp866_general_ci", MaxLen: 1, IsDefault: true},
+		96:  &Charset{Id: 96, Name: "cp932", Collate: "cp932_bin", MaxLen: 2, IsDefault: false},
+		95:  &Charset{Id: 95, Name: "cp932", Collate: "cp932_japanese_ci", MaxLen: 2, IsDefault: true},
+		69:  &Charset{Id: 69, Name: "dec8", Collate: "dec8_bin", MaxLen: 1, IsDefault: false},
+		3:   &Charset{Id: 3, Name: "dec8", Collate: "dec8_swedish_ci", MaxLen: 1, IsDefault: true},
+		97:  &Charset{Id: 97, Name: "eucjpms", Collate: "eucjpms_japanese_ci", MaxLen: 3, IsDefault: true},
+		98:  &Charset{Id: 98, Name: "eucjpms", Collate: "eucjpms_bin", MaxLen: 3, IsDefault: false},
+		85:  &Charset{Id: 85, Name: "euckr", Collate: "euckr_bin", MaxLen: 2, IsDefault: false},
+		19:  &Charset{Id: 19, Name: "euckr", Collate: "euckr_korean_ci", MaxLen: 2, IsDefault: true},
+		250: &Charset{Id: 250, Name: "gb18030", Collate: "gb18030_unicode_520_ci", MaxLen: 4, IsDefault: false},
+		249: &Charset{Id: 249, Name: "gb18030", Collate: "gb18030_bin", MaxLen: 4, IsDefault: false},
+		248: &Charset{Id: 248, Name: "gb18030", Collate: "gb18030_chinese_ci", MaxLen: 4, IsDefault: true},
+		86:  &Charset{Id: 86, Name: "gb2312", Collate: "gb2312_bin", MaxLen: 2, IsDefault: false},
+		24:  &Charset{Id: 24, Name: "gb2312", Collate: "gb2312_chinese_ci", MaxLen: 2, IsDefault: true},
+		87:  &Charset{Id: 87, Name: "gbk", Collate: "gbk_bin", MaxLen: 2, IsDefault: false},
+		28:  &Charset{Id: 28, Name: "gbk", Collate: "gbk_chinese_ci", MaxLen: 2, IsDefault: true},
+		93:  &Charset{Id: 93, Name: "geostd8", Collate: "geostd8_bin", MaxLen: 1, IsDefault: false},
+		92:  &Charset{Id: 92, Name: "geostd8", Collate: "geostd8_general_ci", MaxLen: 1, IsDefault: true},
+		25:  &Charset{Id: 25, Name: "greek", Collate: "greek_general_ci", MaxLen: 1, IsDefault: true},
+		70:  &Charset{Id: 70, Name: "greek", Collate: "greek_bin", MaxLen: 1, IsDefault: false},
+		71:  &Charset{Id: 71, Name: "hebrew", Collate: "hebrew_bin", MaxLen: 1, IsDefault: false},
+		16:  &Charset{Id: 16, Name: "hebrew", Collate: "hebrew_general_ci", MaxLen: 1, IsDefault: true},
+		72:  &Charset{Id: 72, Name: "hp8", Collate: "hp8_bin", MaxLen: 1, IsDefault: false},
+		6:   &Charset{Id: 6, Name: "hp8", Collate: "hp8_english_ci", MaxLen: 1, IsDefault: true},
+		73:  &Charset{Id: 73, Name: "keybcs2", Collate: "keybcs2_bin", MaxLen: 1, IsDefault: false},
+		37:  &Charset{Id: 37, Name: "keybcs2", Collate: "keybcs2_general_ci", MaxLen: 1, IsDefault: true},
+		74:  &Charset{Id: 74, Name: "koi8r", Collate: "koi8r_bin", MaxLen: 1, IsDefault: false},
+		7:   &Charset{Id: 7, Name: "koi8r", Collate: "koi8r_general_ci", MaxLen: 1, IsDefault: true},
+		75:  &Charset{Id: 75, Name: "koi8u", Collate: "koi8u_bin", MaxLen: 1, IsDefault: false},
+		22:  &Charset{Id: 22, Name: "koi8u", Collate: "koi8u_general_ci", MaxLen: 1, IsDefault: true},
+		94:  &Charset{Id: 94, Name: "latin1", Collate: "latin1_spanish_ci", MaxLen: 1, IsDefault: false},
+		49:  &Charset{Id: 49, Name: "latin1", Collate: "latin1_general_cs", MaxLen: 1, IsDefault: false},
+		48:  &Charset{Id: 48, Name: "latin1", Collate: "latin1_general_ci", MaxLen: 1, IsDefault: false},
+		47:  &Charset{Id: 47, Name: "latin1", Collate: "latin1_bin", MaxLen: 1, IsDefault: false},
+		31:  &Charset{Id: 31, Name: "latin1", Collate: "latin1_german2_ci", MaxLen: 1, IsDefault: false},
+		15:  &Charset{Id: 15, Name: "latin1", Collate: "latin1_danish_ci", MaxLen: 1, IsDefault: false},
+		8:   &Charset{Id: 8, Name: "latin1", Collate: "latin1_swedish_ci", MaxLen: 1, IsDefault: true},
+		5:   &Charset{Id: 5, Name: "latin1", Collate: "latin1_german1_ci", MaxLen: 1, IsDefault: false},
+		77:  &Charset{Id: 77, Name: "latin2", Collate: "latin2_bin", MaxLen: 1, IsDefault: false},
+		27:  &Charset{Id: 27, Name: "latin2", Collate: "latin2_croatian_ci", MaxLen: 1, IsDefault: false},
+		21:  &Charset{Id: 21, Name: "latin2", Collate: "latin2_hungarian_ci", MaxLen: 1, IsDefault: false},
+		9:   &Charset{Id: 9, Name: "latin2", Collate: "latin2_general_ci", MaxLen: 1, IsDefault: true},
+		2:   &Charset{Id: 2, Name: "latin2", Collate: "latin2_czech_cs", MaxLen: 1, IsDefault: false},
+		78:  &Charset{Id: 78, Name: "latin5", Collate: "latin5_bin", MaxLen: 1, IsDefault: false},
+		30:  &Charset{Id: 30, Name: "latin5", Collate: "latin5_turkish_ci", MaxLen: 1, IsDefault: true},
+		79:  &Charset{Id: 79, Name: "latin7", Collate: "latin7_bin", MaxLen: 1, IsDefault: false},
+		42:  &Charset{Id: 42, Name: "latin7", Collate: "latin7_general_cs", MaxLen: 1, IsDefault: false},
+		41:  &Charset{Id: 41, Name: "latin7", Collate: "latin7_general_ci", MaxLen: 1, IsDefault: true},
+		20:  &Charset{Id: 20, Name: "latin7", Collate: "latin7_estonian_cs", MaxLen: 1, IsDefault: false},
+		43:  &Charset{Id: 43, Name: "macce", Collate: "macce_bin", MaxLen: 1, IsDefault: false},
+		38:  &Charset{Id: 38, Name: "macce", Collate: "macce_general_ci", MaxLen: 1, IsDefault: true},
+		53:  &Charset{Id: 53, Name: "macroman", Collate: "macroman_bin", MaxLen: 1, IsDefault: false},
+		39:  &Charset{Id: 39, Name: "macroman", Collate: "macroman_general_ci", MaxLen: 1, IsDefault: true},
+		88:  &Charset{Id: 88, Name: "sjis", Collate: "sjis_bin", MaxLen: 2, IsDefault: false},
+		13:  &Charset{Id: 13, Name: "sjis", Collate: "sjis_japanese_ci", MaxLen: 2, IsDefault: true},
+		82:  &Charset{Id: 82, Name: "swe7", Collate: "swe7_bin", MaxLen: 1, IsDefault: false},
+		10:  &Charset{Id: 10, Name: "swe7", Collate: "swe7_swedish_ci", MaxLen: 1, IsDefault: true},
+		18:  &Charset{Id: 18, Name: "tis620", Collate: "tis620_thai_ci", MaxLen: 1, IsDefault: true},
+		89:  &Charset{Id: 89, Name: "tis620", Collate: "tis620_bin", MaxLen: 1, IsDefault: false},
+		35:  &Charset{Id: 35, Name: "ucs2", Collate: "ucs2_general_ci", MaxLen: 2, IsDefault: true},
+		134: &Charset{Id: 134, Name: "ucs2", Collate: "ucs2_estonian_ci", MaxLen: 2, IsDefault: false},
+		142: &Charset{Id: 142, Name: "ucs2", Collate: "ucs2_spanish2_ci", MaxLen: 2, IsDefault: false},
+		150: &Charset{Id: 150, Name: "ucs2", Collate: "ucs2_unicode_520_ci", MaxLen: 2, IsDefault: false},
+		133: &Charset{Id: 133, Name: "ucs2", Collate: "ucs2_polish_ci", MaxLen: 2, IsDefault: false},
+		141: &Charset{Id: 141, Name: "ucs2", Collate: "ucs2_slovak_ci", MaxLen: 2, IsDefault: false},
+		149: &Charset{Id: 149, Name: "ucs2", Collate: "ucs2_croatian_ci", MaxLen: 2, IsDefault: false},
+		132: &Charset{Id: 132, Name: "ucs2", Collate: "ucs2_slovenian_ci", MaxLen: 2, IsDefault: false},
+		140: &Charset{Id: 140, Name: "ucs2", Collate: "ucs2_lithuanian_ci", MaxLen: 2, IsDefault: false},
+		148: &Charset{Id: 148, Name: "ucs2", Collate: "ucs2_german2_ci", MaxLen: 2, IsDefault: false},
+		131: &Charset{Id: 131, Name: "ucs2", Collate: "ucs2_romanian_ci", MaxLen: 2, IsDefault: false},
+		139: &Charset{Id: 139, Name: "ucs2", Collate: "ucs2_danish_ci", MaxLen: 2, IsDefault: false},
+		147: &Charset{Id: 147, Name: "ucs2", Collate: "ucs2_sinhala_ci", MaxLen: 2, IsDefault: false},
+		130: &Charset{Id: 130, Name: "ucs2", Collate: "ucs2_latvian_ci", MaxLen: 2, IsDefault: false},
+		138: &Charset{Id: 138, Name: "ucs2", Collate: "ucs2_czech_ci", MaxLen: 2, IsDefault: false},
+		146: &Charset{Id: 146, Name: "ucs2", Collate: "ucs2_hungarian_ci", MaxLen: 2, IsDefault: false},
+		129: &Charset{Id: 129, Name: "ucs2", Collate: "ucs2_icelandic_ci", MaxLen: 2, IsDefault: false},
+		137: &Charset{Id: 137, Name: "ucs2", Collate: "ucs2_turkish_ci", MaxLen: 2, IsDefault: false},
+		145: &Charset{Id: 145, Name: "ucs2", Collate: "ucs2_esperanto_ci", MaxLen: 2, IsDefault: false},
+		128: &Charset{Id: 128, Name: "ucs2", Collate: "ucs2_unicode_ci", MaxLen: 2, IsDefault: false},
+		136: &Charset{Id: 136, Name: "ucs2", Collate: "ucs2_swedish_ci", MaxLen: 2, IsDefault: false},
+		144: &Charset{Id: 144, Name: "ucs2", Collate: "ucs2_persian_ci", MaxLen: 2, IsDefault: false},
+		159: &Charset{Id: 159, Name: "ucs2", Collate: "ucs2_general_mysql500_ci", MaxLen: 2, IsDefault: false},
+		90:  &Charset{Id: 90, Name: "ucs2", Collate: "ucs2_bin", MaxLen: 2, IsDefault: false},
+		135: &Charset{Id: 135, Name: "ucs2", Collate: "ucs2_spanish_ci", MaxLen: 2, IsDefault: false},
+		143: &Charset{Id: 143, Name: "ucs2", Collate: "ucs2_roman_ci", MaxLen: 2, IsDefault: false},
+		151: &Charset{Id: 151, Name: "ucs2", Collate: "ucs2_vietnamese_ci", MaxLen: 2, IsDefault: false},
+		91:  &Charset{Id: 91, Name: "ujis", Collate: "ujis_bin", MaxLen: 3, IsDefault: false},
+		12:  &Charset{Id: 12, Name: "ujis", Collate: "ujis_japanese_ci", MaxLen: 3, IsDefault: true},
+		54:  &Charset{Id: 54, Name: "utf16", Collate: "utf16_general_ci", MaxLen: 4, IsDefault: true},
+		107: &Charset{Id: 107, Name: "utf16", Collate: "utf16_estonian_ci", MaxLen: 4, IsDefault: false},
+		115: &Charset{Id: 115, Name: "utf16", Collate: "utf16_spanish2_ci", MaxLen: 4, IsDefault: false},
+		123: &Charset{Id: 123, Name: "utf16", Collate: "utf16_unicode_520_ci", MaxLen: 4, IsDefault: false},
+		106: &Charset{Id: 106, Name: "utf16", Collate: "utf16_polish_ci", MaxLen: 4, IsDefault: false},
+		114: &Charset{Id: 114, Name: "utf16", Collate: "utf16_slovak_ci", MaxLen: 4, IsDefault: false},
+		122: &Charset{Id: 122, Name: "utf16", Collate: "utf16_croatian_ci", MaxLen: 4, IsDefault: false},
+		105: &Charset{Id: 105, Name: "utf16", Collate: "utf16_slovenian_ci", MaxLen: 4, IsDefault: false},
+		113: &Charset{Id: 113, Name: "utf16", Collate: "utf16_lithuanian_ci", MaxLen: 4, IsDefault: false},
+		121: &Charset{Id: 121, Name: "utf16", Collate: "utf16_german2_ci", MaxLen: 4, IsDefault: false},
+		104: &Charset{Id: 104, Name: "utf16", Collate: "utf16_romanian_ci", MaxLen: 4, IsDefault: false},
+		112: &Charset{Id: 112, Name: "utf16", Collate: "utf16_danish_ci", MaxLen: 4, IsDefault: false},
+		120: &Charset{Id: 120, Name: "utf16", Collate: "utf16_sinhala_ci", MaxLen: 4, IsDefault: false},
+		103: &Charset{Id: 103, Name: "utf16", Collate: "utf16_latvian_ci", MaxLen: 4, IsDefault: false},
+		111: &Charset{Id: 111, Name: "utf16", Collate: "utf16_czech_ci", MaxLen: 4, IsDefault: false},
+		119: &Charset{Id: 119, Name: "utf16", Collate: "utf16_hungarian_ci", MaxLen: 4, IsDefault: false},
+		102: &Charset{Id: 102, Name: "utf16", Collate: "utf16_icelandic_ci", MaxLen: 4, IsDefault: false},
+		110: &Charset{Id: 110, Name: "utf16", Collate: "utf16_turkish_ci", MaxLen: 4, IsDefault: false},
+		118: &Charset{Id: 118, Name: "utf16", Collate: "utf16_esperanto_ci", MaxLen: 4, IsDefault: false},
+		101: &Charset{Id: 101, Name: "utf16", Collate: "utf16_unicode_ci", MaxLen: 4, IsDefault: false},
+		109: &Charset{Id: 109, Name: "utf16", Collate: "utf16_swedish_ci", MaxLen: 4, IsDefault: false},
+		117: &Charset{Id: 117, Name: "utf16", Collate: "utf16_persian_ci", MaxLen: 4, IsDefault: false},
+		55:  &Charset{Id: 55, Name: "utf16", Collate: "utf16_bin", MaxLen: 4, IsDefault: false},
+		108: &Charset{Id: 108, Name: "utf16", Collate: "utf16_spanish_ci", MaxLen: 4, IsDefault: false},
+		116: &Charset{Id: 116, Name: "utf16", Collate: "utf16_roman_ci", MaxLen: 4, IsDefault: false},
+		124: &Charset{Id: 124, Name: "utf16", Collate: "utf16_vietnamese_ci", MaxLen: 4, IsDefault: false},
+		62:  &Charset{Id: 62, Name: "utf16le", Collate: "utf16le_bin", MaxLen: 4, IsDefault: false},
+		56:  &Charset{Id: 56, Name: "utf16le", Collate: "utf16le_general_ci", MaxLen: 4, IsDefault: true},
+		165: &Charset{Id: 165, Name: "utf32", Collate: "utf32_polish_ci", MaxLen: 4, IsDefault: false},
+		173: &Charset{Id: 173, Name: "utf32", Collate: "utf32_slovak_ci", MaxLen: 4, IsDefault: false},
+		181: &Charset{Id: 181, Name: "utf32", Collate: "utf32_croatian_ci", MaxLen: 4, IsDefault: false},
+		164: &Charset{Id: 164, Name: "utf32", Collate: "utf32_slovenian_ci", MaxLen: 4, IsDefault: false},
+		172: &Charset{Id: 172, Name: "utf32", Collate: "utf32_lithuanian_ci", MaxLen: 4, IsDefault: false},
+		180: &Charset{Id: 180, Name: "utf32", Collate: "utf32_german2_ci", MaxLen: 4, IsDefault: false},
+		163: &Charset{Id: 163, Name: "utf32", Collate: "utf32_romanian_ci", MaxLen: 4, IsDefault: false},
+		171: &Charset{Id: 171, Name: "utf32", Collate: "utf32_danish_ci", MaxLen: 4, IsDefault: false},
+		179: &Charset{Id: 179, Name: "utf32", Collate: "utf32_sinhala_ci", MaxLen: 4, IsDefault: false},
+		162: &Charset{Id: 162, Name: "utf32", Collate: "utf32_latvian_ci", MaxLen: 4, IsDefault: false},
+		170: &Charset{Id: 170, Name: "utf32", Collate: "utf32_czech_ci", MaxLen: 4, IsDefault: false},
+		178: &Charset{Id: 178, Name: "utf32", Collate: "utf32_hungarian_ci", MaxLen: 4, IsDefault: false},
+		161: &Charset{Id: 161, Name: "utf32", Collate: "utf32_icelandic_ci", MaxLen: 4, IsDefault: false},
+		169: &Charset{Id: 169, Name: "utf32", Collate: "utf32_turkish_ci", MaxLen: 4, IsDefault: false},
+		177: &Charset{Id: 177, Name: "utf32", Collate: "utf32_esperanto_ci", MaxLen: 4, IsDefault: false},
+		160: &Charset{Id: 160, Name: "utf32", Collate: "utf32_unicode_ci", MaxLen: 4, IsDefault: false},
+		168: &Charset{Id: 168, Name: "utf32", Collate: "utf32_swedish_ci", MaxLen: 4, IsDefault: false},
+		176: &Charset{Id: 176, Name: "utf32", Collate: "utf32_persian_ci", MaxLen: 4, IsDefault: false},
+		61:  &Charset{Id: 61, Name: "utf32", Collate: "utf32_bin", MaxLen: 4, IsDefault: false},
+		167: &Charset{Id: 167, Name: "utf32", Collate: "utf32_spanish_ci", MaxLen: 4, IsDefault: false},
+		175: &Charset{Id: 175, Name: "utf32", Collate: "utf32_roman_ci", MaxLen: 4, IsDefault: false},
+		183: &Charset{Id: 183, Name: "utf32", Collate: "utf32_vietnamese_ci", MaxLen: 4, IsDefault: false},
+		60:  &Charset{Id: 60, Name: "utf32", Collate: "utf32_general_ci", MaxLen: 4, IsDefault: true},
+		166: &Charset{Id: 166, Name: "utf32", Collate: "utf32_estonian_ci", MaxLen: 4, IsDefault: false},
+		174: &Charset{Id: 174, Name: "utf32", Collate: "utf32_spanish2_ci", MaxLen: 4, IsDefault: false},
+		182: &Charset{Id: 182, Name: "utf32", Collate: "utf32_unicode_520_ci", MaxLen: 4, IsDefault: false},
+		193: &Charset{Id: 193, Name: "utf8", Collate: "utf8_icelandic_ci", MaxLen: 3, IsDefault: false},
+		201: &Charset{Id: 201, Name: "utf8", Collate: "utf8_turkish_ci", MaxLen: 3, IsDefault: false},
+		209: &Charset{Id: 209, Name: "utf8", Collate: "utf8_esperanto_ci", MaxLen: 3, IsDefault: false},
+		192: &Charset{Id: 192, Name: "utf8", Collate: "utf8_unicode_ci", MaxLen: 3, IsDefault: false},
+		200: &Charset{Id: 200, Name: "utf8", Collate: "utf8_swedish_ci", MaxLen: 3, IsDefault: false},
+		208: &Charset{Id: 208, Name: "utf8", Collate: "utf8_persian_ci", MaxLen: 3, IsDefault: false},
+		223: &Charset{Id: 223, Name: "utf8", Collate: "utf8_general_mysql500_ci", MaxLen: 3, IsDefault: false},
+		83:  &Charset{Id: 83, Name: "utf8", Collate: "utf8_bin", MaxLen: 3, IsDefault: false},
+		199: &Charset{Id: 199, Name: "utf8", Collate: "utf8_spanish_ci", MaxLen: 3, IsDefault: false},
+		207: &Charset{Id: 207, Name: "utf8", Collate: "utf8_roman_ci", MaxLen: 3, IsDefault: false},
+		215: &Charset{Id: 215, Name: "utf8", Collate: "utf8_vietnamese_ci", MaxLen: 3, IsDefault: false},
+		198: &Charset{Id: 198, Name: "utf8", Collate: "utf8_estonian_ci", MaxLen: 3, IsDefault: false},
+		206: &Charset{Id: 206, Name: "utf8", Collate: "utf8_spanish2_ci", MaxLen: 3, IsDefault: false},
+		214: &Charset{Id: 214, Name: "utf8", Collate: "utf8_unicode_520_ci", MaxLen: 3, IsDefault: false},
+		33:  &Charset{Id: 33, Name: "utf8", Collate: "utf8_general_ci", MaxLen: 3, IsDefault: true},
+		197: &Charset{Id: 197, Name: "utf8", Collate: "utf8_polish_ci", MaxLen: 3, IsDefault: false},
+		205: &Charset{Id: 205, Name: "utf8", Collate: "utf8_slovak_ci", MaxLen: 3, IsDefault: false},
+		213: &Charset{Id: 213, Name: "utf8", Collate: "utf8_croatian_ci", MaxLen: 3, IsDefault: false},
+		196: &Charset{Id: 196, Name: "utf8", Collate: "utf8_slovenian_ci", MaxLen: 3, IsDefault: false},
+		204: &Charset{Id: 204, Name: "utf8", Collate: "utf8_lithuanian_ci", MaxLen: 3, IsDefault: false},
+		212: &Charset{Id: 212, Name: "utf8", Collate: "utf8_german2_ci", MaxLen: 3, IsDefault: false},
+		195: &Charset{Id: 195, Name: "utf8", Collate: "utf8_romanian_ci", MaxLen: 3, IsDefault: false},
+		203: &Charset{Id: 203, Name: "utf8", Collate: "utf8_danish_ci", MaxLen: 3, IsDefault: false},
+		211: &Charset{Id: 211, Name: "utf8", Collate: "utf8_sinhala_ci", MaxLen: 3, IsDefault: false},
+		194: &Charset{Id: 194, Name: "utf8", Collate: "utf8_latvian_ci", MaxLen: 3, IsDefault: false},
+		202: &Charset{Id: 202, Name: "utf8", Collate: "utf8_czech_ci", MaxLen: 3, IsDefault: false},
+		210: &Charset{Id: 210, Name: "utf8", Collate: "utf8_hungarian_ci", MaxLen: 3, IsDefault: false},
+		229: &Charset{Id: 229, Name: "utf8mb4", Collate: "utf8mb4_polish_ci", MaxLen: 4, IsDefault: false},
+		237: &Charset{Id: 237, Name: "utf8mb4", Collate: "utf8mb4_slovak_ci", MaxLen: 4, IsDefault: false},
+		245: &Charset{Id: 245, Name: "utf8mb4", Collate: "utf8mb4_croatian_ci", MaxLen: 4, IsDefault: false},
+		228: &Charset{Id: 228, Name: "utf8mb4", Collate: "utf8mb4_slovenian_ci", MaxLen: 4, IsDefault: false},
+		236: &Charset{Id: 236, Name: "utf8mb4", Collate: "utf8mb4_lithuanian_ci", MaxLen: 4, IsDefault: false},
+		244: &Charset{Id: 244, Name: "utf8mb4", Collate: "utf8mb4_german2_ci", MaxLen: 4, IsDefault: false},
+		227: &Charset{Id: 227, Name: "utf8mb4", Collate: "utf8mb4_romanian_ci", MaxLen: 4, IsDefault: false},
+		235: &Charset{Id: 235, Name: "utf8mb4", Collate: "utf8mb4_danish_ci", MaxLen: 4, IsDefault: false},
+		243: &Charset{Id: 243, Name: "utf8mb4", Collate: "utf8mb4_sinhala_ci", MaxLen: 4, IsDefault: false},
+		226: &Charset{Id: 226, Name: "utf8mb4", Collate: "utf8mb4_latvian_ci", MaxLen: 4, IsDefault: false},
+		234: &Charset{Id: 234, Name: "utf8mb4", Collate: "utf8mb4_czech_ci", MaxLen: 4, IsDefault: false},
+		242: &Charset{Id: 242, Name: "utf8mb4", Collate: "utf8mb4_hungarian_ci", MaxLen: 4, IsDefault: false},
+		225: &Charset{Id: 225, Name: "utf8mb4", Collate: "utf8mb4_icelandic_ci", MaxLen: 4, IsDefault: false},
+		233: &Charset{Id: 233, Name: "utf8mb4", Collate: "utf8mb4_turkish_ci", MaxLen: 4, IsDefault: false},
+		241: &Charset{Id: 241, Name: "utf8mb4", Collate: "utf8mb4_esperanto_ci", MaxLen: 4, IsDefault: false},
+		224: &Charset{Id: 224, Name: "utf8mb4", Collate: "utf8mb4_unicode_ci", MaxLen: 4, IsDefault: false},
+		232: &Charset{Id: 232, Name: "utf8mb4", Collate: "utf8mb4_swedish_ci", MaxLen: 4, IsDefault: false},
+		240: &Charset{Id: 240, Name: "utf8mb4", Collate: "utf8mb4_persian_ci", MaxLen: 4, IsDefault: false},
+		46:  &Charset{Id: 46, Name: "utf8mb4", Collate: "utf8mb4_bin", MaxLen: 4, IsDefault: false},
+		231: &Charset{Id: 231, Name: "utf8mb4", Collate: "utf8mb4_spanish_ci", MaxLen: 4, IsDefault: false},
+		239: &Charset{Id: 239, Name: "utf8mb4", Collate: "utf8mb4_roman_ci", MaxLen: 4, IsDefault: false},
+		247: &Charset{Id: 247, Name: "utf8mb4", Collate: "utf8mb4_vietnamese_ci", MaxLen: 4, IsDefault: false},
+		45:  &Charset{Id: 45, Name: "utf8mb4", Collate: "utf8mb4_general_ci", MaxLen: 4, IsDefault: true},
+		230: &Charset{Id: 230, Name: "utf8mb4", Collate: "utf8mb4_estonian_ci", MaxLen: 4, IsDefault: false},
+		238: &Charset{Id: 238, Name: "utf8mb4", Collate: "utf8mb4_spanish2_ci", MaxLen: 4, IsDefault: false},
+		246: &Charset{Id: 246, Name: "utf8mb4", Collate: "utf8mb4_unicode_520_ci", MaxLen: 4, IsDefault: false},
+		// MySQL 8.0
+		76:  &Charset{Id: 76, Name: "utf8", Collate: "utf8_tolower_ci", MaxLen: 3, IsDefault: false},
+		255: &Charset{Id: 255, Name: "utf8mb4", Collate: "utf8mb4_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		256: &Charset{Id: 256, Name: "utf8mb4", Collate: "utf8mb4_de_pb_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		257: &Charset{Id: 257, Name: "utf8mb4", Collate: "utf8mb4_is_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		258: &Charset{Id: 258, Name: "utf8mb4", Collate: "utf8mb4_lv_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		259: &Charset{Id: 259, Name: "utf8mb4", Collate: "utf8mb4_ro_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		260: &Charset{Id: 260, Name: "utf8mb4", Collate: "utf8mb4_sl_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		261: &Charset{Id: 261, Name: "utf8mb4", Collate: "utf8mb4_pl_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		262: &Charset{Id: 262, Name: "utf8mb4", Collate: "utf8mb4_et_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		263: &Charset{Id: 263, Name: "utf8mb4", Collate: "utf8mb4_es_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		264: &Charset{Id: 264, Name: "utf8mb4", Collate: "utf8mb4_sv_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		265: &Charset{Id: 265, Name: "utf8mb4", Collate: "utf8mb4_tr_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		266: &Charset{Id: 266, Name: "utf8mb4", Collate: "utf8mb4_cs_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		267: &Charset{Id: 267, Name: "utf8mb4", Collate: "utf8mb4_da_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		268: &Charset{Id: 268, Name: "utf8mb4", Collate: "utf8mb4_lt_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		269: &Charset{Id: 269, Name: "utf8mb4", Collate: "utf8mb4_sk_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		270: &Charset{Id: 270, Name: "utf8mb4", Collate: "utf8mb4_es_trad_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		271: &Charset{Id: 271, Name: "utf8mb4", Collate: "utf8mb4_la_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		273: &Charset{Id: 273, Name: "utf8mb4", Collate: "utf8mb4_eo_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		274: &Charset{Id: 274, Name: "utf8mb4", Collate: "utf8mb4_hu_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		275: &Charset{Id: 275, Name: "utf8mb4", Collate: "utf8mb4_hr_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		277: &Charset{Id: 277, Name: "utf8mb4", Collate: "utf8mb4_vi_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		278: &Charset{Id: 278, Name: "utf8mb4", Collate: "utf8mb4_0900_as_cs", MaxLen: 4, IsDefault: false},
+		279: &Charset{Id: 279, Name: "utf8mb4", Collate: "utf8mb4_de_pb_0900_as_cs", MaxLen: 4, IsDefault: false},
+		280: &Charset{Id: 280, Name: "utf8mb4", Collate: "utf8mb4_is_0900_as_cs", MaxLen: 4, IsDefault: false},
+		281: &Charset{Id: 281, Name: "utf8mb4", Collate: "utf8mb4_lv_0900_as_cs", MaxLen: 4, IsDefault: false},
+		282: &Charset{Id: 282, Name: "utf8mb4", Collate: "utf8mb4_ro_0900_as_cs", MaxLen: 4, IsDefault: false},
+		283: &Charset{Id: 283, Name: "utf8mb4", Collate: "utf8mb4_sl_0900_as_cs", MaxLen: 4, IsDefault: false},
+		284: &Charset{Id: 284, Name: "utf8mb4", Collate: "utf8mb4_pl_0900_as_cs", MaxLen: 4, IsDefault: false},
+		285: &Charset{Id: 285, Name: "utf8mb4", Collate: "utf8mb4_et_0900_as_cs", MaxLen: 4, IsDefault: false},
+		286: &Charset{Id: 286, Name: "utf8mb4", Collate: "utf8mb4_es_0900_as_cs", MaxLen: 4, IsDefault: false},
+		287: &Charset{Id: 287, Name: "utf8mb4", Collate: "utf8mb4_sv_0900_as_cs", MaxLen: 4, IsDefault: false},
+		288: &Charset{Id: 288, Name: "utf8mb4", Collate: "utf8mb4_tr_0900_as_cs", MaxLen: 4, IsDefault: false},
+		289: &Charset{Id: 289, Name: "utf8mb4", Collate: "utf8mb4_cs_0900_as_cs", MaxLen: 4, IsDefault: false},
+		290: &Charset{Id: 290, Name: "utf8mb4", Collate: "utf8mb4_da_0900_as_cs", MaxLen: 4, IsDefault: false},
+		291: &Charset{Id: 291, Name: "utf8mb4", Collate: "utf8mb4_lt_0900_as_cs", MaxLen: 4, IsDefault: false},
+		292: &Charset{Id: 292, Name: "utf8mb4", Collate: "utf8mb4_sk_0900_as_cs", MaxLen: 4, IsDefault: false},
+		293: &Charset{Id: 293, Name: "utf8mb4", Collate: "utf8mb4_es_trad_0900_as_cs", MaxLen: 4, IsDefault: false},
+		294: &Charset{Id: 294, Name: "utf8mb4", Collate: "utf8mb4_la_0900_as_cs", MaxLen: 4, IsDefault: false},
+		296: &Charset{Id: 296, Name: "utf8mb4", Collate: "utf8mb4_eo_0900_as_cs", MaxLen: 4, IsDefault: false},
+		297: &Charset{Id: 297, Name: "utf8mb4", Collate: "utf8mb4_hu_0900_as_cs", MaxLen: 4, IsDefault: false},
+		298: &Charset{Id: 298, Name: "utf8mb4", Collate: "utf8mb4_hr_0900_as_cs", MaxLen: 4, IsDefault: false},
+		300: &Charset{Id: 300, Name: "utf8mb4", Collate: "utf8mb4_vi_0900_as_cs", MaxLen: 4, IsDefault: false},
+		303: &Charset{Id: 303, Name: "utf8mb4", Collate: "utf8mb4_ja_0900_as_cs", MaxLen: 4, IsDefault: false},
+		304: &Charset{Id: 304, Name: "utf8mb4", Collate: "utf8mb4_ja_0900_as_cs_ks", MaxLen: 4, IsDefault: false},
+		305: &Charset{Id: 305, Name: "utf8mb4", Collate: "utf8mb4_0900_as_ci", MaxLen: 4, IsDefault: false},
+		306: &Charset{Id: 306, Name: "utf8mb4", Collate: "utf8mb4_ru_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		307: &Charset{Id: 307, Name: "utf8mb4", Collate: "utf8mb4_ru_0900_as_cs", MaxLen: 4, IsDefault: false},
+		308: &Charset{Id: 308, Name: "utf8mb4", Collate: "utf8mb4_zh_0900_as_cs", MaxLen: 4, IsDefault: false},
+		309: &Charset{Id: 309, Name: "utf8mb4", Collate: "utf8mb4_0900_bin", MaxLen: 4, IsDefault: false},
+		310: &Charset{Id: 310, Name: "utf8mb4", Collate: "utf8mb4_nb_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		311: &Charset{Id: 311, Name: "utf8mb4", Collate: "utf8mb4_nb_0900_as_cs", MaxLen: 4, IsDefault: false},
+		312: &Charset{Id: 312, Name: "utf8mb4", Collate: "utf8mb4_nn_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		313: &Charset{Id: 313, Name: "utf8mb4", Collate: "utf8mb4_nn_0900_as_cs", MaxLen: 4, IsDefault: false},
+		314: &Charset{Id: 314, Name: "utf8mb4", Collate: "utf8mb4_sr_latn_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		315: &Charset{Id: 315, Name: "utf8mb4", Collate: "utf8mb4_sr_latn_0900_as_cs", MaxLen: 4, IsDefault: false},
+		316: &Charset{Id: 316, Name: "utf8mb4", Collate: "utf8mb4_bs_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		317: &Charset{Id: 317, Name: "utf8mb4", Collate: "utf8mb4_bs_0900_as_cs", MaxLen: 4, IsDefault: false},
+		318: &Charset{Id: 318, Name: "utf8mb4", Collate: "utf8mb4_bg_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		319: &Charset{Id: 319, Name: "utf8mb4", Collate: "utf8mb4_bg_0900_as_cs", MaxLen: 4, IsDefault: false},
+		320: &Charset{Id: 320, Name: "utf8mb4", Collate: "utf8mb4_gl_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		321: &Charset{Id: 321, Name: "utf8mb4", Collate: "utf8mb4_gl_0900_as_cs", MaxLen: 4, IsDefault: false},
+		322: &Charset{Id: 322, Name: "utf8mb4", Collate: "utf8mb4_mn_cyrl_0900_ai_ci", MaxLen: 4, IsDefault: false},
+		323: &Charset{Id: 323, Name: "utf8mb4", Collate: "utf8mb4_mn_cyrl_0900_as_cs", MaxLen: 4, IsDefault: false},
+		// MariaDB
+		576: &Charset{Id: 576, Name: "utf8", Collate: "utf8_croatian_mysql561_ci", MaxLen: 3, IsDefault: false},
+		577: &Charset{Id: 577, Name: "utf8", Collate: "utf8_myanmar_ci", MaxLen: 3, IsDefault: false},
+		578: &Charset{Id: 578, Name: "utf8", Collate: "utf8_thai_520_w2", MaxLen: 3, IsDefault: false},
+		608: &Charset{Id: 608, Name: "utf8mb4", Collate: "utf8mb4_croatian_mysql561_ci", MaxLen: 4, IsDefault: false},
+		609: &Charset{Id: 609, Name: "utf8mb4", Collate: "utf8mb4_myanmar_ci", MaxLen: 4, IsDefault: false},
+		610: &Charset{Id: 610, Name: "utf8mb4", Collate: "utf8mb4_thai_520_w2", MaxLen: 4, IsDefault: false},
+		640: &Charset{Id: 640, Name: "utf16", Collate: "utf16_croatian_mysql561_ci", MaxLen: 4, IsDefault: false},
+		641: &Charset{Id: 641, Name: "utf16", Collate: "utf16_myanmar_ci", MaxLen: 4, IsDefault: false},
+		642: &Charset{Id: 642, Name: "utf16", Collate: "utf16_thai_520_w2", MaxLen: 4, IsDefault: false},
+		672: &Charset{Id: 672, Name: "ucs2", Collate: "ucs2_croatian_mysql561_ci", MaxLen: 2, IsDefault: false},
+		673: &Charset{Id: 673, Name: "ucs2", Collate: "ucs2_myanmar_ci", MaxLen: 2, IsDefault: false},
+		674: &Charset{Id: 674, Name: "ucs2", Collate: "ucs2_thai_520_w2", MaxLen: 2, IsDefault: false},
+		736: &Charset{Id: 736, Name: "utf32", Collate: "utf32_croatian_mysql561_ci", MaxLen: 4, IsDefault: false},
+		737: &Charset{Id: 737, Name: "utf32", Collate: "utf32_myanmar_ci", MaxLen: 4, IsDefault: false},
+		738: &Charset{Id: 738, Name: "utf32", Collate: "utf32_thai_520_w2", MaxLen: 4, IsDefault: false},
+	}
+)
+
+func init() {
+	for id, cs := range charsets {
+		if id > maxNopadBaseId || mySQLOnlyCharsets[cs.Name] || cs.Id == binaryCharset {
+			continue
+		}
+		if !cs.IsDefault && !strings.HasSuffix(cs.Collate, "_bin") && !strings.Contains(cs.Collate, "_unicode_") {
+			continue
+		}
+		nopad := cs.Name + "_nopad_bin"
+		if strings.HasSuffix(cs.Collate, "_ci") {
+			nopad = strings.TrimSuffix(cs.Collate, "_ci") + "_nopad_ci"
+		}
+		if _, ok := charsets[id+nopadIdOffset]; !ok {
+			charsets[id+nopadIdOffset] = &Charset{Id: id + nopadIdOffset, Name: cs.Name, Collate: nopad, MaxLen: cs.MaxLen}
+		}
+	}
+	for _, cs := range charsets {
+		collates[cs.Collate] = cs
+		cs.Encoding = charsetEncodings[cs.Name]
+	}
+}
+
+// CharsetById returns the collation with the given id.
+func CharsetById(id int) (*Charset, error) {
+	if cs, ok := charsets[id]; ok {
+		return cs, nil
+	}
+	return nil, UnknownCharsetErr
+}
+
+// CharsetByName returns the collation with the given name. The utf8mb3
+// names of MySQL 8.0 are accepted for the utf8 collations.
+func CharsetByName(collate string) (*Charset, error) {
+	collate = strings.ToLower(collate)
+	if strings.HasPrefix(collate, utf8mb3Prefix) {
+		collate = "utf8" + strings.TrimPrefix(collate, utf8mb3Prefix)
+	}
+	if cs, ok := collates[collate]; ok {
+		return cs, nil
+	}
+	return nil, UnknownCharsetErr
+}
+
+// DefaultCharset returns the primary collation of the named character
+// set on the server of the given version.
+func DefaultCharset(name string, mySQLVersionId uint32) (*Charset, error) {
+	name = strings.ToLower(name)
+	if name == utf8mb3Prefix {
+		name = "utf8"
+	}
+	if id, ok := defaultCollates80[name]; ok && (Version{Id: mySQLVersionId}).IsMySQL80() {
+		return CharsetById(id)
+	}
+	for _, cs := range charsets {
+		if cs.Name == name && cs.IsDefault {
+			return cs, nil
+		}
+	}
+	return nil, UnknownCharsetErr
+}
+
+// Charsets returns all known collations ordered by id.
+func Charsets() []*Charset {
+	list := make([]*Charset, 0, len(charsets))
+	for _, cs := range charsets {
+		list = append(list, cs)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Id < list[j].Id
+	})
+	return list
+}
+
+// IsPrimary reports whether the collation is the primary one of its
+// character set on the server of the given version.
+func (cs *Charset) IsPrimary(mySQLVersionId uint32) bool {
+	if id, ok := defaultCollates80[cs.Name]; ok && (Version{Id: mySQLVersionId}).IsMySQL80() {
+		return cs.Id == id
+	}
+	return cs.IsDefault
+}
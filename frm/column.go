@@ -2,7 +2,19 @@ package frm
 
 import (
 	"encoding/binary"
+	"encoding/hex"
 	"io"
+	"strings"
+)
+
+var (
+	// character sets with characters of at least two bytes
+	wideCharsets = map[string]bool{
+		"ucs2":    true,
+		"utf16":   true,
+		"utf16le": true,
+		"utf32":   true,
+	}
 )
 
 type column struct {
@@ -15,13 +27,42 @@ type column struct {
 	fieldType    uint8
 	charset      uint8
 	comentLength uint16
+	recPos       int
 	name         string
+	comment      string
+	intervals    []string
+	hasDefault   bool
+	defaultValue string
+	invisible    bool
+}
+
+// Column describes a column of the table. Type is written the way SHOW
+// CREATE TABLE of the release that wrote the file prints it and Charset
+// is nil for types without a character set. Length is the size in bytes
+// of strings and the display width of numbers. Default is set when
+// HasDefault is, a nullable column without one defaults to NULL.
+// DefaultExpr marks a default that is an expression, like
+// CURRENT_TIMESTAMP, rather than a value, and OnUpdate is the expression
+// of ON UPDATE. Invisible columns are left out of SELECT *.
+type Column struct {
+	Name          string
+	Type          string
+	Charset       *Charset
+	Length        int
+	Nullable      bool
+	HasDefault    bool
+	Default       string
+	DefaultExpr   bool
+	OnUpdate      string
+	AutoIncrement bool
+	Comment       string
+	Invisible     bool
 }
 
 func (c *column) read(d []byte) {
 	// skip 3
 	c.fieldLength = binary.LittleEndian.Uint16(d[3:5])
-	// skip 2
+	c.recPos = int(d[5]) | int(d[6])<<8 | int(d[7])<<16
 	c.unireg = d[7]
 	c.flags = binary.LittleEndian.Uint16(d[8:10])
 	c.uniregType = d[10]
@@ -32,155 +73,129 @@ func (c *column) read(d []byte) {
 	c.comentLength = binary.LittleEndian.Uint16(d[15:17])
 }
 
+func (c *column) model(f *Frm) Column {
+	return c.modelFor(f.Version())
+}
+
+// modelFor returns the column as the release v prints it.
+func (c *column) modelFor(v Version) Column {
+	b := &strings.Builder{}
+	c.writeShowType(b, v)
+	m := Column{
+		Name:          c.name,
+		Type:          b.String(),
+		Length:        int(c.fieldLength),
+		Nullable:      c.isNullable(),
+		AutoIncrement: c.uniregType == nextNumberUnireg,
+		Comment:       c.comment,
+		Invisible:     c.invisible,
+	}
+	if c.hasCharset() {
+		m.Charset = c.charsetA()
+	}
+	switch {
+	case m.AutoIncrement || (c.flags&noDefaultFieldFlag) != 0:
+	case c.uniregType == timeStampDnUnireg || c.uniregType == timeStampDnUnUnireg:
+		b.Reset()
+		c.writeCurrentTimeStamp(b, v)
+		m.HasDefault = true
+		m.DefaultExpr = true
+		m.Default = b.String()
+	case c.hasDefault:
+		m.HasDefault = true
+		m.Default = c.defaultValue
+	}
+	if c.uniregType == timeStampUnUnireg || c.uniregType == timeStampDnUnUnireg {
+		b.Reset()
+		c.writeCurrentTimeStamp(b, v)
+		m.OnUpdate = b.String()
+	}
+	return m
+}
+
 func (c *column) charsetNum() int {
 	return (int(c.charsetLow) << 8) + int(c.charset)
 }
 
-func (c *column) charsetA() *charset {
-	return charsets[c.charsetNum()]
+// charsetA returns the collation of the column, binary when the file has
+// an id the registry does not know, so the writers and decoders of damaged
+// or foreign files do not fail on it. knownCharset tells the cases apart.
+func (c *column) charsetA() *Charset {
+	if cs, ok := charsets[c.charsetNum()]; ok {
+		return cs
+	}
+	return charsets[binaryCharset]
 }
 
-func (c *column) maxLen() int {
-	return c.charsetA().maxLen
+func (c *column) knownCharset() bool {
+	_, ok := charsets[c.charsetNum()]
+	return ok
 }
 
-func (c *column) writeSize(w io.Writer) {
-	writeParened(w, int(c.fieldLength))
+func (c *column) maxLen() int {
+	return c.charsetA().MaxLen
 }
 
-func (c *column) writeSign(w io.Writer) {
-	if (int(c.flags) & signedFieldFlag) == 0 {
-		writeString(w, " UNSIGNED")
+func (c *column) hasCharset() bool {
+	switch c.fieldType {
+	case varCharFieldType,
+		varStringFieldType,
+		stringFieldType,
+		enumFieldType,
+		setFieldType,
+		tinyBlobFieldType,
+		mediumBlobFieldType,
+		longBlobFieldType,
+		blobFieldType:
+		return true
 	}
+	return false
 }
 
-func (c *column) writeCharset(w io.Writer) {
-	cs := c.charsetA()
-	writeString(w, " CHARACTER SET ")
-	writeString(w, cs.name)
-	writeString(w, " COLLATE ")
-	writeString(w, cs.collate)
+func (c *column) isWide() bool {
+	return wideCharsets[c.charsetA().Name]
 }
 
-func (c *column) writeSized(w io.Writer, s string) {
-	writeString(w, s)
-	c.writeSize(w)
+// unhexIntervals decodes the ENUM and SET values of wide character sets
+// .frm files keep in hex, as their bytes may collide with separators.
+func (c *column) unhexIntervals() {
+	values := make([]string, len(c.intervals))
+	for i, s := range c.intervals {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return
+		}
+		values[i] = string(b)
+	}
+	c.intervals = values
 }
 
-func (c *column) writeSigned(w io.Writer, s string) {
-	writeString(w, s)
-	c.writeSign(w)
+func (c *column) decimals() int {
+	return (int(c.flags) >> decimalShift) & decimalMask
 }
 
-func (c *column) writeSizedSigned(w io.Writer, s string) {
-	writeString(w, s)
-	c.writeSize(w)
-	c.writeSign(w)
+// precision returns the number of digits of a DECIMAL column, whose
+// length also counts the point and the sign.
+func (c *column) precision() int {
+	p := int(c.fieldLength) - (int(c.flags) & signedFieldFlag)
+	if c.decimals() > 0 {
+		p--
+	}
+	return p
 }
 
-func (c *column) writeBlob(w io.Writer, s string) {
-	writeString(w, s)
-	if c.charsetNum() == binaryCharset {
-		writeString(w, "BLOB")
-	} else {
-		writeString(w, "TEXT")
-		c.writeCharset(w)
-	}
+func (c *column) isUnsigned() bool {
+	return (int(c.flags) & signedFieldFlag) == 0
 }
 
-func (c *column) writeBinary(w io.Writer, s string) {
-	cn := c.charsetNum()
-	writeString(w, s)
-	if cn == binaryCharset {
-		writeString(w, "BINARY")
-		c.writeSize(w)
-	} else {
-		cs := c.charsetA()
-		writeString(w, "CHAR")
-		writeParened(w, int(c.fieldLength)/cs.maxLen)
-		c.writeCharset(w)
-	}
+func (c *column) isZeroFill() bool {
+	return (c.flags & zeroFillFieldFlag) != 0
 }
 
-func (c *column) write(w io.Writer) {
-	writeQuoted(w, c.name)
-	writeSpace(w)
-	switch c.fieldType {
-	case newDateFieldType:
-		writeString(w, "DATE")
-	case dateTime2FieldType:
-		writeString(w, "DATETIME")
-	case time2FieldType:
-		writeString(w, "TIME")
-	case timeStamp2FieldType:
-		writeString(w, "TIMESTAMP DEFAULT '2000-01-01'")
-	case geometryFieldType:
-		switch c.charsetNum() {
-		case geometryGeomType:
-			writeString(w, "GEOMETRY")
-		case pointGeomType:
-			writeString(w, "POINT")
-		case lineStringGeomType:
-			writeString(w, "LINESTRING")
-		case polygonGeomType:
-			writeString(w, "POLYGON")
-		case multiPointGeomType:
-			writeString(w, "MULTIPOINT")
-		case multiLineStrintGeomType:
-			writeString(w, "MULTILINESTRING")
-		case multiPolygonGeomType:
-			writeString(w, "MULTIPOLYGON")
-		case geometryCollectionGeomType:
-			writeString(w, "GEOMETRYCOLLECTION")
-		}
-	case doubleFieldType:
-		c.writeSigned(w, "DOUBLE")
-	case floatFieldType:
-		c.writeSigned(w, "FLOAT")
-	case bitFieldType:
-		c.writeSized(w, "BIT")
-	case tinyFieldType:
-		c.writeSizedSigned(w, "TINYINT")
-	case shortFieldType:
-		c.writeSizedSigned(w, "SMALLINT")
-	case longFieldType:
-		c.writeSizedSigned(w, "INT")
-	case int24FieldType:
-		c.writeSizedSigned(w, "MEDIUMINT")
-	case longLongFieldType:
-		c.writeSizedSigned(w, "BIGINT")
-	case tinyBlobFieldType:
-		c.writeBlob(w, "TINY")
-	case mediumBlobFieldType:
-		c.writeBlob(w, "MEDIUM")
-	case longBlobFieldType:
-		c.writeBlob(w, "LONG")
-	case blobFieldType:
-		c.writeBlob(w, emptyString)
-	case varCharFieldType:
-		c.writeBinary(w, "VAR")
-	case stringFieldType:
-		c.writeBinary(w, emptyString)
-	case newDecimalFieldType:
-		writeString(w, "DECIMAL")
-		i := int(c.fieldLength) - (int(c.flags) & signedFieldFlag)
-		f := (int(c.flags) >> decimalShift) & decimalMask
-		if f > 0 {
-			i--
-		}
-		writeOpenParen(w)
-		writeNumber(w, i)
-		writeComma(w)
-		writeNumber(w, f)
-		writeCloseParen(w)
-		c.writeSign(w)
-	default:
-		writeString(w, "<UNKNOWN_TYPE>")
-	}
-	if (c.flags & nullableFieldFlag) == 0 {
-		writeString(w, " NOT NULL")
-	}
-	if c.uniregType == 15 {
-		writeString(w, " AUTO_INCREMENT")
-	}
+func (c *column) isNullable() bool {
+	return (c.flags & nullableFieldFlag) != 0
+}
+
+func (c *column) writeSize(w io.Writer) {
+	writeParened(w, int(c.fieldLength))
 }
@@ -21,6 +21,7 @@ const (
 	timeStamp2FieldType = 17
 	dateTime2FieldType  = 18
 	time2FieldType      = 19
+	jsonFieldType       = 245
 	newDecimalFieldType = 246
 	enumFieldType       = 247
 	setFieldType        = 248
@@ -45,13 +46,17 @@ const (
 )
 
 const (
-	nullableFieldFlag = 0x8000
-	signedFieldFlag   = 0x0001
+	nullableFieldFlag  = 0x8000
+	noDefaultFieldFlag = 0x4000
+	zeroFillFieldFlag  = 0x0004
+	signedFieldFlag    = 0x0001
+	binaryFieldFlag    = 0x0001
 )
 
 const (
 	decimalShift = 8
 	decimalMask  = 0x1f
+	notFixedDec  = 31
 )
 
 const (
@@ -59,6 +64,13 @@ const (
 	viewFileType  = 0x5954
 )
 
+const (
+	oldFrmVersion       = 9
+	mySQL50FileVersion  = 5
+	engineNameVersionId = 50100
+	oldDefaultCharset   = 8
+)
+
 const (
 	frmStructSize    = 64
 	keyStructSize    = 8
@@ -83,5 +95,77 @@ const (
 )
 
 const (
-	allowDupsKeyFlag = 0x0001
+	allowDupsKeyFlag     = 0x0001
+	fullTextKeyFlag      = 0x0080
+	spatialKeyFlag       = 0x0400
+	usesCommentKeyFlag   = 0x1000
+	usesParserKeyFlag    = 0x4000
+	usesBlockSizeKeyFlag = 0x8000
+)
+
+const (
+	reverseSortPartFlag = 0x80
+)
+
+const (
+	namesSep       = 0xff
+	fieldNrMask    = 0x3fff
+	primaryKeyName = "PRIMARY"
+)
+
+const (
+	nextNumberUnireg    = 15
+	timeStampOldUnireg  = 18
+	timeStampDnUnireg   = 21
+	timeStampUnUnireg   = 22
+	timeStampDnUnUnireg = 23
+)
+
+const (
+	packRecordOption        = 0x0001
+	packKeysOption          = 0x0002
+	checksumOption          = 0x0020
+	delayKeyWriteOption     = 0x0040
+	noPackKeysOption        = 0x0080
+	statsPersistentOption   = 0x1000
+	noStatsPersistentOption = 0x2000
+)
+
+const (
+	statsAutoRecalcOn  = 1
+	statsAutoRecalcOff = 2
+)
+
+const (
+	formInfoSize      = 256
+	formCommentPos    = 46
+	longCommentLength = 255
+)
+
+const (
+	filPageType       = 24
+	filPageData       = 38
+	filNull           = 0xffffffff
+	fspSpaceFlags     = 54
+	pageLevel         = 64
+	pageNewInfimum    = 99
+	pageNewSupremum   = 112
+	sdiPageType       = 17853
+	sdiBlobPageType   = 17854
+	blobPageType      = 10
+	sdiTableType      = 1
+	sdiRecordDataPos  = 33
+	blobRefSize       = 20
+	recordStatusMask  = 0x07
+	recordDeletedFlag = 0x20
+)
+
+const (
+	pageSsizeShift  = 6
+	pageSsizeMask   = 0x0f
+	zipSsizeShift   = 1
+	zipSsizeMask    = 0x0f
+	encryptionFlag  = 0x2000
+	sdiFlag         = 0x4000
+	defaultPageSize = 16384
 )
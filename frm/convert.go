@@ -0,0 +1,283 @@
+package frm
+
+import (
+	"io"
+	"strings"
+)
+
+const (
+	mySQL80MinCollationId = 255
+	mySQL80MaxCollationId = 323
+	utf8ToLowerCollation  = 76
+	mariaDBMinCollationId = 576
+	utf8mb4BinCollation   = 46
+	gb18030CharsetName    = "gb18030"
+	binCollateSuffix      = "_bin"
+	myISAMEngine          = "MyISAM"
+	ariaEngine            = "Aria"
+	tableObject           = "table"
+)
+
+var (
+	// storage formats of MySQL 5.6.4 for the older temporal types
+	newTemporalTypes = map[uint8]uint8{
+		timeFieldType:      time2FieldType,
+		dateTimeFieldType:  dateTime2FieldType,
+		timeStampFieldType: timeStamp2FieldType,
+	}
+)
+
+// Conversion is a change made to a table definition so that a server
+// release accepts it. Lossy changes alter what the table stores or how
+// it behaves.
+type Conversion struct {
+	Object  string
+	Message string
+	Lossy   bool
+}
+
+func (c Conversion) String() string {
+	s := c.Object + ": " + c.Message
+	if c.Lossy {
+		s += " (lossy)"
+	}
+	return s
+}
+
+type converter struct {
+	v           Version
+	conversions []Conversion
+}
+
+func (cv *converter) note(object, message string, lossy bool) {
+	cv.conversions = append(cv.conversions, Conversion{Object: object, Message: message, Lossy: lossy})
+}
+
+func columnObject(name string) string {
+	return "column " + QuoteIdent(name)
+}
+
+func keyObject(name string) string {
+	return "key " + QuoteIdent(name)
+}
+
+// WriteCreateTableFor writes a CREATE TABLE statement in the syntax of
+// the release v and returns the changes made for v to accept it.
+func (f *Frm) WriteCreateTableFor(w io.Writer, table string, v Version) []Conversion {
+	t, conversions := f.ConvertTo(v)
+	t.WriteShowCreateTable(w, table, v)
+	return conversions
+}
+
+// ConvertTo returns a copy of the definition changed so that the release
+// v accepts it, along with the changes made.
+func (f *Frm) ConvertTo(v Version) (*Frm, []Conversion) {
+	t := *f
+	t.columns = make([]column, len(f.columns))
+	copy(t.columns, f.columns)
+	t.keys = make([]key, 0, len(f.keys))
+	for _, k := range f.keys {
+		k.parts = append([]part(nil), k.parts...)
+		t.keys = append(t.keys, k)
+	}
+	cv := &converter{v: v}
+	cv.convertTable(&t)
+	for i := range t.columns {
+		cv.convertColumn(&t.columns[i])
+	}
+	cv.convertTimeStamps(&t)
+	keys := t.keys[:0]
+	for i := range t.keys {
+		if cv.convertKey(&t, &t.keys[i]) {
+			keys = append(keys, t.keys[i])
+		}
+	}
+	t.keys = keys
+	return &t, cv.conversions
+}
+
+// isCollationAvailable reports whether the release v has the collation.
+func isCollationAvailable(cs *Charset, v Version) bool {
+	switch {
+	case cs.Id >= mariaDBMinCollationId:
+		return v.IsMariaDB()
+	case cs.Id == utf8ToLowerCollation, cs.Id >= mySQL80MinCollationId && cs.Id <= mySQL80MaxCollationId:
+		return v.IsMySQL80()
+	case cs.Name == gb18030CharsetName:
+		return !v.IsMariaDB() && v.Id >= 50704
+	}
+	return true
+}
+
+// availableCollation returns the collation to use on v instead of cs: the
+// binary or primary collation of the same character set, or the primary
+// utf8mb4 one when v lacks the character set.
+func availableCollation(cs *Charset, v Version) *Charset {
+	if isCollationAvailable(cs, v) {
+		return cs
+	}
+	if cs.Id >= nopadIdOffset {
+		if pad, ok := charsets[cs.Id-nopadIdOffset]; ok && isCollationAvailable(pad, v) {
+			return pad
+		}
+	}
+	if strings.HasSuffix(cs.Collate, binCollateSuffix) {
+		if bin, ok := collates[cs.Name+binCollateSuffix]; ok && isCollationAvailable(bin, v) {
+			return bin
+		}
+	}
+	if def, err := DefaultCharset(cs.Name, v.Id); err == nil && isCollationAvailable(def, v) {
+		return def
+	}
+	def, _ := DefaultCharset("utf8mb4", v.Id)
+	return def
+}
+
+func (cv *converter) convertTable(t *Frm) {
+	v := cv.v
+	if cs := t.tableCharset(); cs != nil {
+		if to := availableCollation(cs, v); to != cs {
+			t.defaultCharset = uint8(to.Id)
+			t.charsetLow = uint8(to.Id >> 8)
+			cv.note(tableObject, "collation "+cs.Collate+" is replaced by "+to.Collate, true)
+		}
+	}
+	engine := t.Engine()
+	if engine == ariaEngine && !v.IsMariaDB() {
+		t.engineName = myISAMEngine
+		cv.note(tableObject, "engine Aria is replaced by MyISAM", true)
+	}
+	if t.partitionInfo != emptyString && v.IsMySQL80() && t.Engine() != innoDBEngine {
+		t.partitionInfo = emptyString
+		cv.note(tableObject, "partitioning of "+t.Engine()+" tables is not supported and is removed", true)
+	}
+	statsOptions := uint16(statsPersistentOption | noStatsPersistentOption)
+	if v.Id < 50606 && ((t.tableOptions&statsOptions) != 0 || t.statAutoRecalc != 0 || t.statSamplePages != 0) {
+		t.tableOptions &^= statsOptions
+		t.statAutoRecalc = 0
+		t.statSamplePages = 0
+		cv.note(tableObject, "persistent statistics options are removed", false)
+	}
+}
+
+func (cv *converter) convertColumn(c *column) {
+	v := cv.v
+	object := columnObject(c.name)
+	switch c.fieldType {
+	case timeFieldType, dateTimeFieldType, timeStampFieldType:
+		if v.Id >= 50604 {
+			c.fieldType = newTemporalTypes[c.fieldType]
+			cv.note(object, "temporal type is converted to the MySQL 5.6.4 storage format", false)
+		}
+	case decimalFieldType:
+		c.fieldType = newDecimalFieldType
+		cv.note(object, "DECIMAL is converted to the MySQL 5.0.3 storage format", false)
+	case varStringFieldType:
+		c.fieldType = varCharFieldType
+		cv.note(object, "VARCHAR is converted to the MySQL 5.0.3 storage format", false)
+	case yearFieldType:
+		if c.fieldLength == 2 && v.Id >= 50705 {
+			c.fieldLength = 4
+			cv.note(object, "YEAR(2) is converted to YEAR(4)", true)
+		}
+	case jsonFieldType:
+		if v.IsMariaDB() || v.Id < 50708 {
+			c.fieldType = longBlobFieldType
+			c.charset = utf8mb4BinCollation
+			c.charsetLow = 0
+			cv.note(object, "JSON is converted to LONGTEXT and values are not validated", true)
+		}
+	case tinyFieldType, shortFieldType, int24FieldType, longFieldType, longLongFieldType:
+		if v.hidesDisplayWidth() && !c.isZeroFill() && !(c.fieldType == tinyFieldType && c.fieldLength == 1) {
+			cv.note(object, "integer display width is removed", false)
+		}
+	}
+	switch c.fieldType {
+	case time2FieldType:
+		cv.convertFraction(c, timeWidth)
+	case dateTime2FieldType, timeStamp2FieldType:
+		cv.convertFraction(c, dateTimeWidth)
+	}
+	if c.fieldType == dateTime2FieldType && c.uniregType >= timeStampDnUnireg && v.Id < 50605 {
+		c.uniregType = 0
+		c.hasDefault = false
+		cv.note(object, "CURRENT_TIMESTAMP of DATETIME is removed", true)
+	}
+	if c.hasCharset() {
+		cs := c.charsetA()
+		if to := availableCollation(cs, v); to != cs {
+			c.charset = uint8(to.Id)
+			c.charsetLow = uint8(to.Id >> 8)
+			cv.note(object, "collation "+cs.Collate+" is replaced by "+to.Collate, true)
+		}
+	}
+	if c.invisible && !v.hasInvisibleColumns() {
+		c.invisible = false
+		cv.note(object, "INVISIBLE is removed, the column is visible", true)
+	}
+}
+
+// convertFraction removes fractional seconds on releases before 5.6.4.
+func (cv *converter) convertFraction(c *column, width int) {
+	if cv.v.Id >= 50604 || c.fractionDigits(width) == 0 {
+		return
+	}
+	c.fieldLength = uint16(width)
+	if i := strings.IndexByte(c.defaultValue, '.'); i >= 0 {
+		c.defaultValue = c.defaultValue[:i]
+	}
+	cv.note(columnObject(c.name), "fractional seconds are removed", true)
+}
+
+// convertTimeStamps keeps CURRENT_TIMESTAMP on the first TIMESTAMP
+// column only, as releases before 5.6.5 allow.
+func (cv *converter) convertTimeStamps(t *Frm) {
+	if cv.v.Id >= 50605 {
+		return
+	}
+	found := false
+	for i := range t.columns {
+		c := &t.columns[i]
+		if !c.isTimeStamp() || c.uniregType < timeStampDnUnireg {
+			continue
+		}
+		if found {
+			c.uniregType = 0
+			c.hasDefault = false
+			cv.note(columnObject(c.name), "CURRENT_TIMESTAMP of a second TIMESTAMP column is removed", true)
+		}
+		found = true
+	}
+}
+
+// convertKey changes the key for the release and returns false when the
+// key has to be removed.
+func (cv *converter) convertKey(t *Frm, k *key) bool {
+	v := cv.v
+	object := keyObject(k.name)
+	isInnoDB := t.Engine() == innoDBEngine
+	if (k.flags&fullTextKeyFlag) != 0 && isInnoDB && v.Id < 50600 {
+		cv.note(object, "FULLTEXT keys of InnoDB tables are not supported and the key is removed", true)
+		return false
+	}
+	if (k.flags&spatialKeyFlag) != 0 && isInnoDB && v.Id < 50705 {
+		cv.note(object, "SPATIAL keys of InnoDB tables are not supported and the key is removed", true)
+		return false
+	}
+	for _, p := range k.parts {
+		if p.expr != emptyString && !v.hasFunctionalKeys() {
+			cv.note(object, "functional key parts are not supported and the key is removed", true)
+			return false
+		}
+	}
+	if !v.hasDescKeys() {
+		for i := range k.parts {
+			p := &k.parts[i]
+			if p.isDesc() {
+				p.keyPartFlag &^= reverseSortPartFlag
+				cv.note(object, "descending order of "+QuoteIdent(t.columns[p.fieldNumA()].name)+" is removed", true)
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,12 @@
+CREATE TABLE `t40` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `code` char(8) CHARACTER SET latin1 COLLATE latin1_bin NOT NULL DEFAULT '',
+  `name` varchar(32) NOT NULL DEFAULT 'none',
+  `price` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `note` text,
+  `data` blob,
+  `state` enum('new','done') NOT NULL DEFAULT 'new',
+  `changed` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `name` (`name`)
+) ENGINE=MyISAM DEFAULT CHARSET=latin1
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE `t41` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `code` char(8) CHARACTER SET latin1 COLLATE latin1_bin NOT NULL DEFAULT '',
+  `name` varchar(32) CHARACTER SET utf8 NOT NULL DEFAULT 'none',
+  `price` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `note` text COMMENT 'free text',
+  `created` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `code` (`code`),
+  KEY `name` (`name`(10))
+) ENGINE=InnoDB DEFAULT CHARSET=latin1 COMMENT='orders of 4.1'
\ No newline at end of file
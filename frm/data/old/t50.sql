@@ -0,0 +1,8 @@
+CREATE TABLE `t50` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `sku` varchar(16) NOT NULL DEFAULT '',
+  `amount` decimal(12,2) NOT NULL DEFAULT '0.00',
+  `added` datetime NOT NULL DEFAULT '0000-00-00 00:00:00',
+  PRIMARY KEY (`id`),
+  KEY `sku` (`sku`)
+) ENGINE=FEDERATED DEFAULT CHARSET=utf8 CONNECTION='mysql://app@db1:3306/shop/t50'
\ No newline at end of file
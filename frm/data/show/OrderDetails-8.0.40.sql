@@ -0,0 +1,6 @@
+CREATE TABLE `OrderDetails` (
+  `order_detail_id` int NOT NULL AUTO_INCREMENT,
+  `order_id` int NOT NULL,
+  `goods_id` int NOT NULL,
+  PRIMARY KEY (`order_detail_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE `Orders` (
+  `order_id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `status` tinyint(3) unsigned NOT NULL,
+  PRIMARY KEY (`order_id`),
+  KEY `HX_Orders_user_id_status` (`user_id`,`status`) USING HASH
+) ENGINE=InnoDB DEFAULT CHARSET=utf8
\ No newline at end of file
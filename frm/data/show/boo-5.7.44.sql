@@ -0,0 +1,12 @@
+CREATE TABLE `boo` (
+  `a` int(11) DEFAULT NULL,
+  `bb` int(11) DEFAULT NULL,
+  `ccc` int(11) DEFAULT NULL,
+  `dddd` int(11) DEFAULT NULL,
+  `eeeee` int(11) DEFAULT NULL,
+  `ffffff` int(11) DEFAULT NULL,
+  `gggggg` int(11) DEFAULT NULL,
+  `hhhhhhhh` int(11) DEFAULT NULL,
+  `iiiiiiiiiiii` int(11) DEFAULT NULL,
+  `mmmmmmmmmmmmm` int(11) DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE `boo` (
+  `a` int DEFAULT NULL,
+  `bb` int DEFAULT NULL,
+  `ccc` int DEFAULT NULL,
+  `dddd` int DEFAULT NULL,
+  `eeeee` int DEFAULT NULL,
+  `ffffff` int DEFAULT NULL,
+  `gggggg` int DEFAULT NULL,
+  `hhhhhhhh` int DEFAULT NULL,
+  `iiiiiiiiiiii` int DEFAULT NULL,
+  `mmmmmmmmmmmmm` int DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3
\ No newline at end of file
@@ -0,0 +1,132 @@
+CREATE TABLE `boo1` (
+  `a00` int(11) DEFAULT NULL,
+  `a01` int(11) DEFAULT NULL,
+  `a02` int(11) DEFAULT NULL,
+  `a03` int(11) DEFAULT NULL,
+  `a04` int(11) DEFAULT NULL,
+  `a05` int(11) DEFAULT NULL,
+  `a06` int(11) DEFAULT NULL,
+  `a07` int(11) DEFAULT NULL,
+  `a08` int(11) DEFAULT NULL,
+  `a09` int(11) DEFAULT NULL,
+  `bb10` int(11) DEFAULT NULL,
+  `bb11` int(11) DEFAULT NULL,
+  `bb12` int(11) DEFAULT NULL,
+  `bb13` int(11) DEFAULT NULL,
+  `bb14` int(11) DEFAULT NULL,
+  `bb15` int(11) DEFAULT NULL,
+  `bb16` int(11) DEFAULT NULL,
+  `bb17` int(11) DEFAULT NULL,
+  `bb18` int(11) DEFAULT NULL,
+  `bb19` int(11) DEFAULT NULL,
+  `ccc10` int(11) DEFAULT NULL,
+  `ccc11` int(11) DEFAULT NULL,
+  `ccc12` int(11) DEFAULT NULL,
+  `ccc13` int(11) DEFAULT NULL,
+  `ccc14` int(11) DEFAULT NULL,
+  `ccc15` int(11) DEFAULT NULL,
+  `ccc16` int(11) DEFAULT NULL,
+  `ccc17` int(11) DEFAULT NULL,
+  `ccc18` int(11) DEFAULT NULL,
+  `ccc19` int(11) DEFAULT NULL,
+  `dddd10` int(11) DEFAULT NULL,
+  `dddd11` int(11) DEFAULT NULL,
+  `dddd12` int(11) DEFAULT NULL,
+  `dddd13` int(11) DEFAULT NULL,
+  `dddd14` int(11) DEFAULT NULL,
+  `dddd15` int(11) DEFAULT NULL,
+  `dddd16` int(11) DEFAULT NULL,
+  `dddd17` int(11) DEFAULT NULL,
+  `dddd18` int(11) DEFAULT NULL,
+  `dddd19` int(11) DEFAULT NULL,
+  `eeeee10` int(11) DEFAULT NULL,
+  `eeeee11` int(11) DEFAULT NULL,
+  `eeeee12` int(11) DEFAULT NULL,
+  `eeeee13` int(11) DEFAULT NULL,
+  `eeeee14` int(11) DEFAULT NULL,
+  `eeeee15` int(11) DEFAULT NULL,
+  `eeeee16` int(11) DEFAULT NULL,
+  `eeeee17` int(11) DEFAULT NULL,
+  `eeeee18` int(11) DEFAULT NULL,
+  `eeeee19` int(11) DEFAULT NULL,
+  `ffffff10` int(11) DEFAULT NULL,
+  `ffffff11` int(11) DEFAULT NULL,
+  `ffffff12` int(11) DEFAULT NULL,
+  `ffffff13` int(11) DEFAULT NULL,
+  `ffffff14` int(11) DEFAULT NULL,
+  `ffffff15` int(11) DEFAULT NULL,
+  `ffffff16` int(11) DEFAULT NULL,
+  `ffffff17` int(11) DEFAULT NULL,
+  `ffffff18` int(11) DEFAULT NULL,
+  `ffffff19` int(11) DEFAULT NULL,
+  `ggggggg10` int(11) DEFAULT NULL,
+  `ggggggg11` int(11) DEFAULT NULL,
+  `ggggggg12` int(11) DEFAULT NULL,
+  `ggggggg13` int(11) DEFAULT NULL,
+  `ggggggg14` int(11) DEFAULT NULL,
+  `ggggggg15` int(11) DEFAULT NULL,
+  `ggggggg16` int(11) DEFAULT NULL,
+  `ggggggg17` int(11) DEFAULT NULL,
+  `ggggggg18` int(11) DEFAULT NULL,
+  `ggggggg19` int(11) DEFAULT NULL,
+  `hhhhhhhh10` int(11) DEFAULT NULL,
+  `hhhhhhhh11` int(11) DEFAULT NULL,
+  `hhhhhhhh12` int(11) DEFAULT NULL,
+  `hhhhhhhh13` int(11) DEFAULT NULL,
+  `hhhhhhhh14` int(11) DEFAULT NULL,
+  `hhhhhhhh15` int(11) DEFAULT NULL,
+  `hhhhhhhh16` int(11) DEFAULT NULL,
+  `hhhhhhhh17` int(11) DEFAULT NULL,
+  `hhhhhhhh18` int(11) DEFAULT NULL,
+  `hhhhhhhh19` int(11) DEFAULT NULL,
+  `jjjjjjjjj10` int(11) DEFAULT NULL,
+  `jjjjjjjjj11` int(11) DEFAULT NULL,
+  `jjjjjjjjj12` int(11) DEFAULT NULL,
+  `jjjjjjjjj13` int(11) DEFAULT NULL,
+  `jjjjjjjjj14` int(11) DEFAULT NULL,
+  `jjjjjjjjj15` int(11) DEFAULT NULL,
+  `jjjjjjjjj16` int(11) DEFAULT NULL,
+  `jjjjjjjjj17` int(11) DEFAULT NULL,
+  `jjjjjjjjj18` int(11) DEFAULT NULL,
+  `jjjjjjjjj19` int(11) DEFAULT NULL,
+  `kkkkkkkkkk10` int(11) DEFAULT NULL,
+  `kkkkkkkkkk11` int(11) DEFAULT NULL,
+  `kkkkkkkkkk12` int(11) DEFAULT NULL,
+  `kkkkkkkkkk13` int(11) DEFAULT NULL,
+  `kkkkkkkkkk14` int(11) DEFAULT NULL,
+  `kkkkkkkkkk15` int(11) DEFAULT NULL,
+  `kkkkkkkkkk16` int(11) DEFAULT NULL,
+  `kkkkkkkkkk17` int(11) DEFAULT NULL,
+  `kkkkkkkkkk18` int(11) DEFAULT NULL,
+  `kkkkkkkkkk19` int(11) DEFAULT NULL,
+  `lllllllllll10` int(11) DEFAULT NULL,
+  `lllllllllll11` int(11) DEFAULT NULL,
+  `lllllllllll12` int(11) DEFAULT NULL,
+  `lllllllllll13` int(11) DEFAULT NULL,
+  `lllllllllll14` int(11) DEFAULT NULL,
+  `lllllllllll15` int(11) DEFAULT NULL,
+  `lllllllllll16` int(11) DEFAULT NULL,
+  `lllllllllll17` int(11) DEFAULT NULL,
+  `lllllllllll18` int(11) DEFAULT NULL,
+  `lllllllllll19` int(11) DEFAULT NULL,
+  `mmmmmmmmmmmm10` int(11) DEFAULT NULL,
+  `mmmmmmmmmmmm11` int(11) DEFAULT NULL,
+  `mmmmmmmmmmmm12` int(11) DEFAULT NULL,
+  `mmmmmmmmmmmm13` int(11) DEFAULT NULL,
+  `mmmmmmmmmmmm14` int(11) DEFAULT NULL,
+  `mmmmmmmmmmmm15` int(11) DEFAULT NULL,
+  `mmmmmmmmmmmm16` int(11) DEFAULT NULL,
+  `mmmmmmmmmmmm17` int(11) DEFAULT NULL,
+  `mmmmmmmmmmmm18` int(11) DEFAULT NULL,
+  `mmmmmmmmmmmm19` int(11) DEFAULT NULL,
+  `zzzzzzzzzzzzz10` int(11) DEFAULT NULL,
+  `zzzzzzzzzzzzz11` int(11) DEFAULT NULL,
+  `zzzzzzzzzzzzz12` int(11) DEFAULT NULL,
+  `zzzzzzzzzzzzz13` int(11) DEFAULT NULL,
+  `zzzzzzzzzzzzz14` int(11) DEFAULT NULL,
+  `zzzzzzzzzzzzz15` int(11) DEFAULT NULL,
+  `zzzzzzzzzzzzz16` int(11) DEFAULT NULL,
+  `zzzzzzzzzzzzz17` int(11) DEFAULT NULL,
+  `zzzzzzzzzzzzz18` int(11) DEFAULT NULL,
+  `zzzzzzzzzzzzz19` int(11) DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8
\ No newline at end of file
@@ -0,0 +1,132 @@
+CREATE TABLE `boo1` (
+  `a00` int DEFAULT NULL,
+  `a01` int DEFAULT NULL,
+  `a02` int DEFAULT NULL,
+  `a03` int DEFAULT NULL,
+  `a04` int DEFAULT NULL,
+  `a05` int DEFAULT NULL,
+  `a06` int DEFAULT NULL,
+  `a07` int DEFAULT NULL,
+  `a08` int DEFAULT NULL,
+  `a09` int DEFAULT NULL,
+  `bb10` int DEFAULT NULL,
+  `bb11` int DEFAULT NULL,
+  `bb12` int DEFAULT NULL,
+  `bb13` int DEFAULT NULL,
+  `bb14` int DEFAULT NULL,
+  `bb15` int DEFAULT NULL,
+  `bb16` int DEFAULT NULL,
+  `bb17` int DEFAULT NULL,
+  `bb18` int DEFAULT NULL,
+  `bb19` int DEFAULT NULL,
+  `ccc10` int DEFAULT NULL,
+  `ccc11` int DEFAULT NULL,
+  `ccc12` int DEFAULT NULL,
+  `ccc13` int DEFAULT NULL,
+  `ccc14` int DEFAULT NULL,
+  `ccc15` int DEFAULT NULL,
+  `ccc16` int DEFAULT NULL,
+  `ccc17` int DEFAULT NULL,
+  `ccc18` int DEFAULT NULL,
+  `ccc19` int DEFAULT NULL,
+  `dddd10` int DEFAULT NULL,
+  `dddd11` int DEFAULT NULL,
+  `dddd12` int DEFAULT NULL,
+  `dddd13` int DEFAULT NULL,
+  `dddd14` int DEFAULT NULL,
+  `dddd15` int DEFAULT NULL,
+  `dddd16` int DEFAULT NULL,
+  `dddd17` int DEFAULT NULL,
+  `dddd18` int DEFAULT NULL,
+  `dddd19` int DEFAULT NULL,
+  `eeeee10` int DEFAULT NULL,
+  `eeeee11` int DEFAULT NULL,
+  `eeeee12` int DEFAULT NULL,
+  `eeeee13` int DEFAULT NULL,
+  `eeeee14` int DEFAULT NULL,
+  `eeeee15` int DEFAULT NULL,
+  `eeeee16` int DEFAULT NULL,
+  `eeeee17` int DEFAULT NULL,
+  `eeeee18` int DEFAULT NULL,
+  `eeeee19` int DEFAULT NULL,
+  `ffffff10` int DEFAULT NULL,
+  `ffffff11` int DEFAULT NULL,
+  `ffffff12` int DEFAULT NULL,
+  `ffffff13` int DEFAULT NULL,
+  `ffffff14` int DEFAULT NULL,
+  `ffffff15` int DEFAULT NULL,
+  `ffffff16` int DEFAULT NULL,
+  `ffffff17` int DEFAULT NULL,
+  `ffffff18` int DEFAULT NULL,
+  `ffffff19` int DEFAULT NULL,
+  `ggggggg10` int DEFAULT NULL,
+  `ggggggg11` int DEFAULT NULL,
+  `ggggggg12` int DEFAULT NULL,
+  `ggggggg13` int DEFAULT NULL,
+  `ggggggg14` int DEFAULT NULL,
+  `ggggggg15` int DEFAULT NULL,
+  `ggggggg16` int DEFAULT NULL,
+  `ggggggg17` int DEFAULT NULL,
+  `ggggggg18` int DEFAULT NULL,
+  `ggggggg19` int DEFAULT NULL,
+  `hhhhhhhh10` int DEFAULT NULL,
+  `hhhhhhhh11` int DEFAULT NULL,
+  `hhhhhhhh12` int DEFAULT NULL,
+  `hhhhhhhh13` int DEFAULT NULL,
+  `hhhhhhhh14` int DEFAULT NULL,
+  `hhhhhhhh15` int DEFAULT NULL,
+  `hhhhhhhh16` int DEFAULT NULL,
+  `hhhhhhhh17` int DEFAULT NULL,
+  `hhhhhhhh18` int DEFAULT NULL,
+  `hhhhhhhh19` int DEFAULT NULL,
+  `jjjjjjjjj10` int DEFAULT NULL,
+  `jjjjjjjjj11` int DEFAULT NULL,
+  `jjjjjjjjj12` int DEFAULT NULL,
+  `jjjjjjjjj13` int DEFAULT NULL,
+  `jjjjjjjjj14` int DEFAULT NULL,
+  `jjjjjjjjj15` int DEFAULT NULL,
+  `jjjjjjjjj16` int DEFAULT NULL,
+  `jjjjjjjjj17` int DEFAULT NULL,
+  `jjjjjjjjj18` int DEFAULT NULL,
+  `jjjjjjjjj19` int DEFAULT NULL,
+  `kkkkkkkkkk10` int DEFAULT NULL,
+  `kkkkkkkkkk11` int DEFAULT NULL,
+  `kkkkkkkkkk12` int DEFAULT NULL,
+  `kkkkkkkkkk13` int DEFAULT NULL,
+  `kkkkkkkkkk14` int DEFAULT NULL,
+  `kkkkkkkkkk15` int DEFAULT NULL,
+  `kkkkkkkkkk16` int DEFAULT NULL,
+  `kkkkkkkkkk17` int DEFAULT NULL,
+  `kkkkkkkkkk18` int DEFAULT NULL,
+  `kkkkkkkkkk19` int DEFAULT NULL,
+  `lllllllllll10` int DEFAULT NULL,
+  `lllllllllll11` int DEFAULT NULL,
+  `lllllllllll12` int DEFAULT NULL,
+  `lllllllllll13` int DEFAULT NULL,
+  `lllllllllll14` int DEFAULT NULL,
+  `lllllllllll15` int DEFAULT NULL,
+  `lllllllllll16` int DEFAULT NULL,
+  `lllllllllll17` int DEFAULT NULL,
+  `lllllllllll18` int DEFAULT NULL,
+  `lllllllllll19` int DEFAULT NULL,
+  `mmmmmmmmmmmm10` int DEFAULT NULL,
+  `mmmmmmmmmmmm11` int DEFAULT NULL,
+  `mmmmmmmmmmmm12` int DEFAULT NULL,
+  `mmmmmmmmmmmm13` int DEFAULT NULL,
+  `mmmmmmmmmmmm14` int DEFAULT NULL,
+  `mmmmmmmmmmmm15` int DEFAULT NULL,
+  `mmmmmmmmmmmm16` int DEFAULT NULL,
+  `mmmmmmmmmmmm17` int DEFAULT NULL,
+  `mmmmmmmmmmmm18` int DEFAULT NULL,
+  `mmmmmmmmmmmm19` int DEFAULT NULL,
+  `zzzzzzzzzzzzz10` int DEFAULT NULL,
+  `zzzzzzzzzzzzz11` int DEFAULT NULL,
+  `zzzzzzzzzzzzz12` int DEFAULT NULL,
+  `zzzzzzzzzzzzz13` int DEFAULT NULL,
+  `zzzzzzzzzzzzz14` int DEFAULT NULL,
+  `zzzzzzzzzzzzz15` int DEFAULT NULL,
+  `zzzzzzzzzzzzz16` int DEFAULT NULL,
+  `zzzzzzzzzzzzz17` int DEFAULT NULL,
+  `zzzzzzzzzzzzz18` int DEFAULT NULL,
+  `zzzzzzzzzzzzz19` int DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3
\ No newline at end of file
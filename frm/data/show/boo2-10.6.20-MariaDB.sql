@@ -0,0 +1,3 @@
+CREATE TABLE `boo2` (
+  `a` int(11) DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3
\ No newline at end of file
@@ -0,0 +1,33 @@
+CREATE TABLE `boo3` (
+  `a0000` decimal(32,0) DEFAULT NULL,
+  `a0001` decimal(32,1) DEFAULT NULL,
+  `a0002` decimal(32,2) DEFAULT NULL,
+  `a0003` decimal(32,3) DEFAULT NULL,
+  `a0004` decimal(32,4) DEFAULT NULL,
+  `a0005` decimal(32,5) DEFAULT NULL,
+  `a0006` decimal(32,6) DEFAULT NULL,
+  `a0007` decimal(32,7) DEFAULT NULL,
+  `a0008` decimal(32,8) DEFAULT NULL,
+  `a0009` decimal(32,9) DEFAULT NULL,
+  `a0010` decimal(32,10) DEFAULT NULL,
+  `a0011` decimal(32,11) DEFAULT NULL,
+  `a0012` decimal(32,12) DEFAULT NULL,
+  `a0013` decimal(32,13) DEFAULT NULL,
+  `a0014` decimal(32,14) DEFAULT NULL,
+  `a0015` decimal(32,15) DEFAULT NULL,
+  `a0016` decimal(32,16) DEFAULT NULL,
+  `a0017` decimal(32,17) DEFAULT NULL,
+  `a0018` decimal(32,18) DEFAULT NULL,
+  `a0019` decimal(32,19) DEFAULT NULL,
+  `a0020` decimal(32,20) DEFAULT NULL,
+  `a0021` decimal(32,21) DEFAULT NULL,
+  `a0022` decimal(32,22) DEFAULT NULL,
+  `a0023` decimal(32,23) DEFAULT NULL,
+  `a0024` decimal(32,24) DEFAULT NULL,
+  `a0025` decimal(32,25) DEFAULT NULL,
+  `a0026` decimal(32,26) DEFAULT NULL,
+  `a0027` decimal(32,27) DEFAULT NULL,
+  `a0028` decimal(32,28) DEFAULT NULL,
+  `a0029` decimal(32,29) DEFAULT NULL,
+  `a0030` decimal(32,30) DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3
\ No newline at end of file
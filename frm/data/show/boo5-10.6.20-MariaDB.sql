@@ -0,0 +1,18 @@
+CREATE TABLE `boo5` (
+  `a0000` decimal(15,0) DEFAULT NULL,
+  `a0001` decimal(15,1) DEFAULT NULL,
+  `a0002` decimal(15,2) DEFAULT NULL,
+  `a0003` decimal(15,3) DEFAULT NULL,
+  `a0004` decimal(15,4) DEFAULT NULL,
+  `a0005` decimal(15,5) DEFAULT NULL,
+  `a0006` decimal(15,6) DEFAULT NULL,
+  `a0007` decimal(15,7) DEFAULT NULL,
+  `a0008` decimal(15,8) DEFAULT NULL,
+  `a0009` decimal(15,9) DEFAULT NULL,
+  `a0010` decimal(15,10) DEFAULT NULL,
+  `a0011` decimal(15,11) DEFAULT NULL,
+  `a0012` decimal(15,12) DEFAULT NULL,
+  `a0013` decimal(15,13) DEFAULT NULL,
+  `a0014` decimal(15,14) DEFAULT NULL,
+  `a0015` decimal(15,15) DEFAULT NULL
+) ENGINE=MyISAM DEFAULT CHARSET=utf8mb3
\ No newline at end of file
@@ -0,0 +1,465 @@
+CREATE TABLE `unknown` (
+  `a1` varchar(154) DEFAULT NULL,
+  `col21` longtext CHARACTER SET ucs2 COLLATE ucs2_sinhala_ci NOT NULL,
+  `col22` time NOT NULL,
+  `col23` double unsigned DEFAULT NULL,
+  `col24` datetime NOT NULL,
+  `col25` linestring NOT NULL,
+  `col26` decimal(15,7) NOT NULL,
+  `col27` date NOT NULL,
+  `col28` bit(47) DEFAULT NULL,
+  `col36` point NOT NULL,
+  `col37` datetime NOT NULL,
+  `col38` linestring DEFAULT NULL,
+  `col39` int(10) unsigned DEFAULT NULL,
+  `col40` point NOT NULL,
+  `col41` varchar(239) NOT NULL,
+  `col42` double unsigned NOT NULL,
+  `col43` bit(17) NOT NULL,
+  `col44` date NOT NULL,
+  `col45` datetime NOT NULL,
+  `col46` time NOT NULL,
+  `col92` blob NOT NULL,
+  `col103` multilinestring DEFAULT NULL,
+  `col104` datetime NOT NULL,
+  `col105` linestring DEFAULT NULL,
+  `col106` geometry NOT NULL,
+  `col130` time NOT NULL,
+  `col131` blob NOT NULL,
+  `col132` smallint(6) DEFAULT NULL,
+  `col133` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col134` blob NOT NULL,
+  `col135` int(10) unsigned NOT NULL,
+  `col147` time DEFAULT NULL,
+  `col148` multipolygon DEFAULT NULL,
+  `col149` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col150` bit(3) DEFAULT NULL,
+  `col151` polygon NOT NULL,
+  `col152` longblob NOT NULL,
+  `col153` binary(1) NOT NULL,
+  `col154` tinyblob NOT NULL,
+  `col155` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col156` binary(1) NOT NULL,
+  `col157` linestring DEFAULT NULL,
+  `col158` double unsigned NOT NULL,
+  `col159` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col160` geometry NOT NULL,
+  `col161` bigint(20) unsigned DEFAULT NULL,
+  `col167` geometry DEFAULT NULL,
+  `col168` multilinestring NOT NULL,
+  `col169` decimal(15,2) unsigned NOT NULL,
+  `col177` geometrycollection DEFAULT NULL,
+  `col178` date DEFAULT NULL,
+  `col179` geometrycollection DEFAULT NULL,
+  `col180` datetime NOT NULL,
+  `col181` decimal(48,20) unsigned NOT NULL,
+  `col182` time DEFAULT NULL,
+  `col190` binary(1) NOT NULL,
+  `col191` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col192` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col193` polygon DEFAULT NULL,
+  `col194` time DEFAULT NULL,
+  `col195` date NOT NULL,
+  `col196` decimal(40,28) NOT NULL,
+  `col197` blob NOT NULL,
+  `col198` double unsigned DEFAULT NULL,
+  `col199` time NOT NULL,
+  `col200` tinytext CHARACTER SET macroman NOT NULL,
+  `col201` time NOT NULL,
+  `col210` multipoint DEFAULT NULL,
+  `col211` geometrycollection DEFAULT NULL,
+  `col212` point DEFAULT NULL,
+  `col220` longblob NOT NULL,
+  `col221` bigint(20) DEFAULT NULL,
+  `col222` multipoint DEFAULT NULL,
+  `col223` tinyblob NOT NULL,
+  `col224` polygon NOT NULL,
+  `col225` multipoint NOT NULL,
+  `col226` mediumblob NOT NULL,
+  `col227` binary(1) NOT NULL,
+  `col228` geometry DEFAULT NULL,
+  `col229` decimal(65,21) DEFAULT NULL,
+  `col230` time NOT NULL,
+  `col239` varchar(237) CHARACTER SET latin1 COLLATE latin1_general_cs NOT NULL,
+  `col249` tinyblob NOT NULL,
+  `col250` double DEFAULT NULL,
+  `col251` datetime NOT NULL,
+  `col252` mediumblob NOT NULL,
+  `col253` decimal(15,5) unsigned NOT NULL,
+  `col254` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col255` char(184) NOT NULL,
+  `col256` geometry NOT NULL,
+  `col257` multilinestring NOT NULL,
+  `col258` multipoint DEFAULT NULL,
+  `col277` multilinestring NOT NULL,
+  `col278` linestring NOT NULL,
+  `col279` datetime NOT NULL,
+  `col288` double DEFAULT NULL,
+  `col289` bigint(20) unsigned NOT NULL,
+  `col290` point DEFAULT NULL,
+  `col291` double unsigned NOT NULL,
+  `col292` double NOT NULL,
+  `col301` tinytext CHARACTER SET geostd8 NOT NULL,
+  `col302` multipolygon DEFAULT NULL,
+  `col303` longtext CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL,
+  `col304` point DEFAULT NULL,
+  `col305` geometrycollection NOT NULL,
+  `col306` double unsigned DEFAULT NULL,
+  `col340` longtext CHARACTER SET ucs2 COLLATE ucs2_swedish_ci NOT NULL,
+  `col341` int(11) DEFAULT NULL,
+  `col342` binary(1) NOT NULL,
+  `col343` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col344` geometrycollection NOT NULL,
+  `col345` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col346` geometrycollection NOT NULL,
+  `col347` longtext CHARACTER SET utf8mb3 COLLATE utf8mb3_unicode_ci NOT NULL,
+  `col348` mediumblob NOT NULL,
+  `col349` date NOT NULL,
+  `col350` blob NOT NULL,
+  `col351` double unsigned DEFAULT NULL,
+  `col352` multilinestring DEFAULT NULL,
+  `col353` binary(1) NOT NULL,
+  `col363` int(10) unsigned DEFAULT NULL,
+  `col365` varchar(133) NOT NULL,
+  `col366` char(36) CHARACTER SET latin5 NOT NULL,
+  `col367` decimal(26,0) DEFAULT NULL,
+  `col368` decimal(35,10) DEFAULT NULL,
+  `col369` tinytext NOT NULL,
+  `col370` float unsigned DEFAULT NULL,
+  `col371` binary(1) NOT NULL,
+  `col372` double unsigned DEFAULT NULL,
+  `col373` blob NOT NULL,
+  `col374` double unsigned DEFAULT NULL,
+  `col375` tinyblob NOT NULL,
+  `col376` point NOT NULL,
+  `col377` datetime DEFAULT NULL,
+  `col378` blob NOT NULL,
+  `col379` polygon NOT NULL,
+  `col380` time DEFAULT NULL,
+  `col381` multilinestring NOT NULL,
+  `col382` text CHARACTER SET cp1250 COLLATE cp1250_polish_ci NOT NULL,
+  `col383` datetime DEFAULT NULL,
+  `col384` binary(1) NOT NULL,
+  `col385` multipoint NOT NULL,
+  `col386` multipoint NOT NULL,
+  `col387` polygon NOT NULL,
+  `col388` date NOT NULL,
+  `col389` multipolygon NOT NULL,
+  `col390` multilinestring DEFAULT NULL,
+  `col391` time DEFAULT NULL,
+  `col392` binary(1) NOT NULL,
+  `col393` decimal(26,14) DEFAULT NULL,
+  `col395` bit(44) NOT NULL,
+  `col396` binary(1) NOT NULL,
+  `col397` point DEFAULT NULL,
+  `col398` mediumblob NOT NULL,
+  `col411` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col412` point DEFAULT NULL,
+  `col413` decimal(54,10) DEFAULT NULL,
+  `col414` multipolygon DEFAULT NULL,
+  `col415` geometrycollection DEFAULT NULL,
+  `col416` date NOT NULL,
+  `col424` polygon DEFAULT NULL,
+  `col425` date DEFAULT NULL,
+  `col463` time DEFAULT NULL,
+  `col464` smallint(5) unsigned DEFAULT NULL,
+  `col465` polygon DEFAULT NULL,
+  `col466` decimal(50,27) NOT NULL,
+  `col467` char(163) NOT NULL,
+  `col552` time DEFAULT NULL,
+  `col553` linestring NOT NULL,
+  `col554` time DEFAULT NULL,
+  `col555` time DEFAULT NULL,
+  `col556` time NOT NULL,
+  `col557` datetime NOT NULL,
+  `col558` varbinary(208) NOT NULL,
+  `col559` double DEFAULT NULL,
+  `col560` time DEFAULT NULL,
+  `col561` polygon DEFAULT NULL,
+  `col589` double DEFAULT NULL,
+  `col590` multipolygon DEFAULT NULL,
+  `col591` multipolygon DEFAULT NULL,
+  `col592` bit(15) NOT NULL,
+  `col593` multipoint DEFAULT NULL,
+  `col594` float NOT NULL,
+  `col595` geometrycollection DEFAULT NULL,
+  `col596` text CHARACTER SET ucs2 COLLATE ucs2_icelandic_ci NOT NULL,
+  `col597` geometrycollection NOT NULL,
+  `col599` bit(46) DEFAULT NULL,
+  `col600` int(11) DEFAULT NULL,
+  `col601` geometrycollection DEFAULT NULL,
+  `col602` char(151) CHARACTER SET utf8mb4 COLLATE utf8mb4_czech_ci NOT NULL,
+  `col603` multipoint DEFAULT NULL,
+  `col604` decimal(62,9) NOT NULL,
+  `col605` tinytext CHARACTER SET ucs2 COLLATE ucs2_roman_ci NOT NULL,
+  `col606` multipolygon DEFAULT NULL,
+  `col608` multipolygon DEFAULT NULL,
+  `col609` bigint(20) DEFAULT NULL,
+  `col610` linestring DEFAULT NULL,
+  `col611` point DEFAULT NULL,
+  `col645` double DEFAULT NULL,
+  `col646` time NOT NULL,
+  `col647` polygon NOT NULL,
+  `col648` date DEFAULT NULL,
+  `col657` double unsigned NOT NULL,
+  `col658` multipolygon DEFAULT NULL,
+  `col659` geometrycollection NOT NULL,
+  `col660` time NOT NULL,
+  `col661` multilinestring DEFAULT NULL,
+  `col662` geometrycollection DEFAULT NULL,
+  `col663` linestring NOT NULL,
+  `col669` int(11) DEFAULT NULL,
+  `col670` varchar(217) NOT NULL,
+  `col671` geometrycollection DEFAULT NULL,
+  `col672` point NOT NULL,
+  `col673` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col757` datetime DEFAULT NULL,
+  `col758` tinyblob NOT NULL,
+  `col759` multipolygon NOT NULL,
+  `col760` date NOT NULL,
+  `col761` geometrycollection DEFAULT NULL,
+  `col762` double NOT NULL,
+  `col813` point DEFAULT NULL,
+  `col814` varchar(126) NOT NULL,
+  `col815` double NOT NULL,
+  `col816` geometrycollection DEFAULT NULL,
+  `col817` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col832` date DEFAULT NULL,
+  `col833` multipoint DEFAULT NULL,
+  `col834` varchar(103) CHARACTER SET utf8mb3 COLLATE utf8mb3_slovenian_ci NOT NULL,
+  `col835` time NOT NULL,
+  `col836` time DEFAULT NULL,
+  `col837` linestring DEFAULT NULL,
+  `col838` binary(1) NOT NULL,
+  `col839` datetime DEFAULT NULL,
+  `col840` float unsigned NOT NULL,
+  `col841` datetime DEFAULT NULL,
+  `col842` char(191) CHARACTER SET utf8mb4 COLLATE utf8mb4_esperanto_ci NOT NULL,
+  `col860` datetime DEFAULT NULL,
+  `col861` tinyint(1) DEFAULT NULL,
+  `col895` geometrycollection NOT NULL,
+  `col896` blob NOT NULL,
+  `col897` multipolygon NOT NULL,
+  `col898` bigint(20) unsigned NOT NULL,
+  `col899` datetime NOT NULL,
+  `col908` datetime NOT NULL,
+  `col946` tinyint(1) DEFAULT NULL,
+  `col947` tinyblob NOT NULL,
+  `col948` char(6) NOT NULL,
+  `col949` varchar(122) NOT NULL,
+  `col950` longtext CHARACTER SET keybcs2 NOT NULL,
+  `col964` multilinestring NOT NULL,
+  `col965` time DEFAULT NULL,
+  `col979` date NOT NULL,
+  `col980` geometry NOT NULL,
+  `col981` varchar(182) CHARACTER SET cp932 NOT NULL,
+  `col982` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col983` linestring NOT NULL,
+  `col984` point NOT NULL,
+  `col1009` geometrycollection NOT NULL,
+  `col1010` multipolygon NOT NULL,
+  `col1011` multipolygon DEFAULT NULL,
+  `col1012` point NOT NULL,
+  `col1013` multipoint NOT NULL,
+  `col1014` binary(1) NOT NULL,
+  `col1015` geometry NOT NULL,
+  `col1016` geometrycollection NOT NULL,
+  `col1017` time DEFAULT NULL,
+  `col1018` geometry DEFAULT NULL,
+  `col1019` mediumblob NOT NULL,
+  `col1020` geometrycollection DEFAULT NULL,
+  `col1021` time NOT NULL,
+  `col1029` mediumtext CHARACTER SET utf8mb3 COLLATE utf8mb3_polish_ci NOT NULL,
+  `col1030` mediumtext CHARACTER SET utf8mb3 COLLATE utf8mb3_danish_ci NOT NULL,
+  `col1031` polygon DEFAULT NULL,
+  `col1032` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col1033` time NOT NULL,
+  `col1063` date DEFAULT NULL,
+  `col1064` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col1065` datetime DEFAULT NULL,
+  `col1066` date DEFAULT NULL,
+  `col1067` binary(1) NOT NULL,
+  `col1068` double DEFAULT NULL,
+  `col1069` multipolygon NOT NULL,
+  `col1070` polygon NOT NULL,
+  `col1071` time DEFAULT NULL,
+  `col1078` multilinestring NOT NULL,
+  `col1079` date NOT NULL,
+  `col1080` char(56) NOT NULL,
+  `col1081` int(10) unsigned DEFAULT NULL,
+  `col1082` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col1095` double DEFAULT NULL,
+  `col1106` double NOT NULL,
+  `col1107` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col1108` mediumint(9) NOT NULL,
+  `col1109` polygon NOT NULL,
+  `col1110` decimal(34,7) unsigned DEFAULT NULL,
+  `col1111` date DEFAULT NULL,
+  `col1112` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col1113` geometrycollection NOT NULL,
+  `col1114` geometry DEFAULT NULL,
+  `col1138` linestring NOT NULL,
+  `col1148` time DEFAULT NULL,
+  `col1150` multipolygon DEFAULT NULL,
+  `col1151` float unsigned DEFAULT NULL,
+  `col1152` char(27) NOT NULL,
+  `col1153` longtext CHARACTER SET cp1250 COLLATE cp1250_czech_cs NOT NULL,
+  `col1154` varbinary(243) NOT NULL,
+  `col1155` double DEFAULT NULL,
+  `col1157` multilinestring DEFAULT NULL,
+  `col1158` geometry NOT NULL,
+  `col1159` date DEFAULT NULL,
+  `col1160` mediumtext CHARACTER SET ujis COLLATE ujis_bin NOT NULL,
+  `col1161` datetime NOT NULL,
+  `col1162` tinytext CHARACTER SET hp8 COLLATE hp8_bin NOT NULL,
+  `col1163` multipoint NOT NULL,
+  `col1164` point DEFAULT NULL,
+  `col1166` tinyblob NOT NULL,
+  `col1167` datetime DEFAULT NULL,
+  `col1168` date DEFAULT NULL,
+  `col1169` polygon DEFAULT NULL,
+  `col1170` geometrycollection DEFAULT NULL,
+  `col1171` polygon NOT NULL,
+  `col1172` bigint(20) unsigned DEFAULT NULL,
+  `col1175` linestring DEFAULT NULL,
+  `col1176` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col1177` polygon NOT NULL,
+  `col1178` binary(1) NOT NULL,
+  `col1179` longtext CHARACTER SET ucs2 NOT NULL,
+  `col1180` date DEFAULT NULL,
+  `col1181` multipoint NOT NULL,
+  `col1182` multipolygon NOT NULL,
+  `col1183` smallint(5) unsigned DEFAULT NULL,
+  `col1185` multipolygon NOT NULL,
+  `col1220` datetime NOT NULL,
+  `col1221` bit(43) NOT NULL,
+  `col1222` polygon DEFAULT NULL,
+  `col1223` point DEFAULT NULL,
+  `col1224` tinyblob NOT NULL,
+  `col1225` text CHARACTER SET koi8r NOT NULL,
+  `col1232` tinytext CHARACTER SET utf8mb3 COLLATE utf8mb3_slovenian_ci NOT NULL,
+  `col1233` polygon NOT NULL,
+  `col1238` datetime DEFAULT NULL,
+  `col1239` smallint(6) NOT NULL,
+  `col1240` point DEFAULT NULL,
+  `col1241` smallint(6) NOT NULL,
+  `col1242` bigint(20) unsigned DEFAULT NULL,
+  `col1243` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col1244` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col1245` multilinestring NOT NULL,
+  `col1277` datetime NOT NULL,
+  `col1278` mediumblob NOT NULL,
+  `col1279` date DEFAULT NULL,
+  `col1289` longblob NOT NULL,
+  `col1290` point DEFAULT NULL,
+  `col1291` polygon DEFAULT NULL,
+  `col1292` longblob NOT NULL,
+  `col1293` text CHARACTER SET cp1251 COLLATE cp1251_bulgarian_ci NOT NULL,
+  `col1297` longtext CHARACTER SET eucjpms NOT NULL,
+  `col1299` datetime DEFAULT NULL,
+  `col1300` mediumblob NOT NULL,
+  `col1301` mediumblob NOT NULL,
+  `col1302` text CHARACTER SET utf8mb3 COLLATE utf8mb3_sinhala_ci NOT NULL,
+  `col1303` text CHARACTER SET koi8u NOT NULL,
+  `col1314` polygon NOT NULL,
+  `col1315` multilinestring NOT NULL,
+  `col1316` date NOT NULL,
+  `col1317` multilinestring DEFAULT NULL,
+  `col1318` int(10) unsigned DEFAULT NULL,
+  `col1319` multipoint DEFAULT NULL,
+  `col1320` char(235) NOT NULL,
+  `col1321` double unsigned DEFAULT NULL,
+  `col1322` multipolygon NOT NULL,
+  `col1323` decimal(61,28) DEFAULT NULL,
+  `col1331` double NOT NULL,
+  `col1332` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  `col1333` multipoint NOT NULL,
+  `col1334` double NOT NULL,
+  `col1335` double unsigned NOT NULL,
+  `col1336` longblob NOT NULL,
+  `col1337` smallint(5) unsigned DEFAULT NULL,
+  `col1338` linestring DEFAULT NULL,
+  `col1340` multipoint NOT NULL,
+  `col1341` datetime NOT NULL,
+  `col1342` polygon DEFAULT NULL,
+  `col1343` mediumint(9) DEFAULT NULL,
+  `col1344` mediumtext CHARACTER SET cp852 NOT NULL,
+  `col1346` mediumtext CHARACTER SET hp8 COLLATE hp8_bin NOT NULL,
+  `col1348` int(11) NOT NULL,
+  `col1349` time DEFAULT NULL,
+  `col1350` geometry DEFAULT NULL,
+  `col1351` float unsigned NOT NULL,
+  `col1352` binary(1) NOT NULL,
+  `col1359` tinytext CHARACTER SET utf8mb3 COLLATE utf8mb3_icelandic_ci NOT NULL,
+  `col1360` linestring DEFAULT NULL,
+  `col1361` datetime NOT NULL,
+  `col1362` tinyint(1) NOT NULL,
+  `col1363` tinytext CHARACTER SET utf8mb4 COLLATE utf8mb4_roman_ci NOT NULL,
+  `col1370` blob NOT NULL,
+  `col1371` polygon DEFAULT NULL,
+  `col1372` timestamp NOT NULL DEFAULT '1999-12-31 21:00:00',
+  UNIQUE KEY `idx4714` (`col1106`,`col596`(218),`col1185`(45),`col21`(154)) USING BTREE,
+  UNIQUE KEY `idx3509` (`col306`) USING BTREE,
+  UNIQUE KEY `idx271` (`col306`,`col28`,`col152`(203),`col757`,`col1350`(210)) USING HASH,
+  UNIQUE KEY `idx1257` (`col353`,`col40`(25),`col1290`(25),`col1302`(12),`col552`,`col412`(25),`col1289`(246),`col660`,`col147`) USING BTREE,
+  UNIQUE KEY `idx1260` (`col1114`(86),`col1020`(33),`col948`(5),`col230`,`col384`,`col1157`(124),`col1066`,`col1361`,`col1015`(146),`col1239`,`col1169`(206),`col302`(111),`col1107`,`col227`) USING HASH,
+  UNIQUE KEY `idx1410` (`col611`(25),`col153`,`col1164`(25),`col41`(165),`col43`),
+  UNIQUE KEY `idx1661` (`col1183`,`col657`,`col388`,`col1171`(41),`col671`(6),`col1291`(239),`col1064`,`col1079`,`col158`,`col1151`,`col599`,`col1350`(207),`col1178`,`col39`) USING BTREE,
+  UNIQUE KEY `idx2959` (`col606`(49),`col1349`,`col351`,`col663`(191),`col464`,`col1152`(24),`col1171`(127),`col1010`(94),`col1299`,`col230`) USING HASH,
+  UNIQUE KEY `idx3108` (`col22`,`col1063`,`col1290`(25),`col947`(2),`col288`,`col351`,`col373`(255),`col1029`(17),`col1332`,`col647`(104),`col1181`(75),`col177`(70),`col758`(41),`col1179`(174)) USING HASH,
+  UNIQUE KEY `idx3396` (`col226`(70),`col839`,`col1161`,`col303`(205),`col984`(25)) USING HASH,
+  UNIQUE KEY `idx3397` (`col840`,`col1021`,`col817`,`col289`,`col1361`,`col558`(51),`col1150`(65),`col395`,`col24`,`col1063`),
+  UNIQUE KEY `idx3511` (`col251`,`col1013`(93),`col190`,`col158`,`col1167`,`col896`(214),`col1278`(234),`col815`) USING BTREE,
+  UNIQUE KEY `idx3980` (`col1020`(230),`col1349`,`col947`(179),`col1240`(25),`col1148`,`col291`,`col42`,`col948`(4),`col899`,`col257`(60),`col222`(207),`col393`) USING HASH,
+  UNIQUE KEY `idx4041` (`col230`,`col191`,`col200`(205),`col609`,`col1154`(128),`col559`,`col28`,`col1337`,`col1359`(51),`col815`,`col1065`,`col1338`(213),`col1321`) USING HASH,
+  UNIQUE KEY `idx4257` (`col1371`(50),`col353`),
+  UNIQUE KEY `idx4374` (`col1337`,`col1113`(185),`col1319`(4),`col602`(42),`col816`(83),`col340`(161),`col1111`,`col608`(192),`col1223`(25),`col28`) USING BTREE,
+  UNIQUE KEY `idx4375` (`col130`,`col762`,`col1162`(115),`col398`(230),`col288`,`col1081`,`col673`,`col1029`(178),`col1033`) USING HASH,
+  UNIQUE KEY `idx5510` (`col398`(117),`col813`(25),`col1344`(24),`col38`(48),`col373`(189),`col393`,`col1068`,`col150`,`col965`,`col1162`(51),`col383`,`col606`(87)) USING HASH,
+  UNIQUE KEY `idx5654` (`col1351`,`col1321`,`col1081`,`col251`,`col1067`,`col1030`(244),`col896`(8),`col374`,`col196`,`col161`,`col211`(145),`col1079`,`col646`,`col757`),
+  KEY `idx270` (`col672`(25),`col1224`(252)),
+  KEY `idx356` (`col671`(158),`col1289`(211)) USING HASH,
+  KEY `idx752` (`col302`(186),`col1352`,`col179`(80),`col201`,`col1321`,`col658`(206),`col1279`,`col1151`),
+  KEY `idx753` (`col156`,`col302`(129),`col1069`(127),`col1220`) USING HASH,
+  KEY `idx1258` (`col558`(123),`col279`,`col984`(25),`col302`(46),`col662`(174),`col343`,`col257`(169),`col1069`(71),`col46`,`col1079`,`col43`) USING HASH,
+  KEY `idx1259` (`col1071`,`col1223`(25),`col278`(216),`col133`) USING BTREE,
+  KEY `idx1261` (`col610`(97)) USING HASH,
+  KEY `idx1262` (`col411`) USING BTREE,
+  KEY `idx1263` (`col1349`,`col381`(59)) USING BTREE,
+  KEY `idx1411` (`col662`(38),`col386`(14),`col305`(63),`col158`) USING BTREE,
+  KEY `idx1574` (`col153`,`col1340`(30),`col896`(2),`col224`(27),`col1138`(218),`col44`,`col396`,`col1033`,`col841`,`col603`(196),`col1337`,`col28`,`col425`),
+  KEY `idx1575` (`col291`,`col1079`,`col277`(148),`col1180`,`col195`,`col1338`(193),`col1157`(127),`col1222`(13),`col353`) USING HASH,
+  KEY `idx1576` (`col1155`,`col948`(1),`col1344`(244),`col43`,`col227`,`col393`,`col398`(101),`col553`(31)),
+  KEY `idx1992` (`col28`,`col133`,`col1343`,`col1289`(142),`col278`(1),`col252`(101),`col592`,`col195`,`col1360`(16),`col896`(8),`col1290`(25),`col1320`(148)),
+  KEY `idx2581` (`col1154`(120)) USING BTREE,
+  KEY `idx2582` (`col673`,`col1013`(200),`col36`(25),`col353`,`col366`(7),`col1346`(78),`col182`,`col24`,`col1010`(60),`col292`,`col27`,`col1172`,`col761`(73)),
+  KEY `idx2583` (`col1163`(18),`col758`(224),`col351`,`col663`(134)),
+  KEY `idx2584` (`col611`(25),`col181`,`col159`,`col663`(226),`col815`,`col195`,`col1021`,`col196`,`col255`(27),`col897`(192)) USING HASH,
+  KEY `idx2585` (`col660`,`col896`(113),`col1081`),
+  KEY `idx2647` (`col1163`(93),`col226`(171),`col229`,`col1111`,`col27`,`col221`,`col381`(38),`col605`(172)),
+  KEY `idx2648` (`col1343`,`col1009`(116),`col1110`,`col306`,`col1183`,`col1333`(126),`col1158`(59),`col839`,`col150`,`col1152`(6),`col224`(223),`col1063`,`col758`(54),`col230`) USING HASH,
+  KEY `idx2649` (`col1277`,`col398`(20),`col979`,`col1110`,`col22`,`col1183`,`col347`(235),`col835`),
+  KEY `idx2650` (`col1070`(106),`col609`,`col1067`,`col178`,`col1159`,`col1323`,`col227`) USING BTREE,
+  KEY `idx2958` (`col156`,`col561`(30),`col836`) USING HASH,
+  KEY `idx2960` (`col179`(84),`col1180`,`col104`,`col277`(2),`col1314`(126),`col1338`(237),`col279`,`col762`,`col222`(227)),
+  KEY `idx3109` (`col646`,`col659`(199),`col599`,`col1162`(7),`col594`,`col1110`,`col198`,`col669`,`col1068`) USING BTREE,
+  KEY `idx3110` (`col662`(128),`col1166`(111),`col1170`(224),`col147`,`col946`,`col148`(5)) USING BTREE,
+  KEY `idx3394` (`col1169`(49),`col1233`(96),`col1063`,`col1303`(91)) USING HASH,
+  KEY `idx3395` (`col1020`(199)) USING BTREE,
+  KEY `idx3508` (`col908`,`col1009`(1),`col594`,`col1176`,`col759`(3),`col1343`,`col1166`(25),`col1371`(89),`col841`,`col253`,`col42`) USING BTREE,
+  KEY `idx3510` (`col1244`,`col817`,`col465`(217),`col288`,`col252`(14),`col1110`,`col1160`(215),`col1318`) USING HASH,
+  KEY `idx3583` (`col948`(5),`col168`(53),`col1163`(5)) USING BTREE,
+  KEY `idx3926` (`col1344`(126),`col1291`(220),`col23`,`col348`(2),`col1244`,`col1016`(173),`col179`(247),`col761`(42),`col1177`(28),`col1148`) USING HASH,
+  KEY `idx3927` (`col366`(16),`col1108`,`col363`,`col415`(105),`col151`(234),`col817`,`col1321`,`col167`(90),`col344`(14),`col1070`(193),`col258`(98),`col1241`,`col1110`,`col92`(48)) USING BTREE,
+  KEY `idx3981` (`col1346`(179),`col412`(25),`col1019`(131),`col1113`(146),`col302`(116),`col1349`,`col591`(41),`col555`,`col229`,`col303`(37)) USING HASH,
+  KEY `idx3982` (`col353`,`col201`,`col372`) USING HASH,
+  KEY `idx4040` (`col657`,`col158`,`col1344`(173)),
+  KEY `idx4256` (`col1112`,`col1323`) USING BTREE,
+  KEY `idx4258` (`col1063`,`col92`(226),`col1348`) USING HASH,
+  KEY `idx4373` (`col816`(157),`col415`(28),`col251`),
+  KEY `idx4450` (`col559`,`col1068`,`col1080`(17),`col662`(120),`col1032`,`col23`,`col193`(249)) USING HASH,
+  KEY `idx4451` (`col840`,`col1151`,`col1290`(25),`col555`,`col553`(211),`col372`,`col1069`(211),`col1361`,`col1169`(90),`col661`(249),`col1172`,`col1021`) USING BTREE,
+  KEY `idx4452` (`col291`,`col425`,`col1182`(134),`col840`,`col39`,`col1320`(33),`col558`(94)) USING HASH,
+  KEY `idx5655` (`col389`(12),`col1107`,`col1290`(25),`col1180`) USING HASH,
+  KEY `idx5656` (`col374`,`col1029`(107),`col306`,`col349`,`col239`(209),`col416`,`col382`(85),`col147`,`col42`,`col412`(25)) USING HASH
+) ENGINE=MyISAM DEFAULT CHARSET=utf8mb3
\ No newline at end of file
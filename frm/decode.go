@@ -0,0 +1,178 @@
+package frm
+
+import (
+	"errors"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+	"strings"
+)
+
+var (
+	UnsupportedCharsetErr = errors.New("Unsupported charset.")
+)
+
+const (
+	binaryCharsetName = "binary"
+	padSpace          = " "
+)
+
+var (
+	// MySQL latin1 is cp1252 and ucs2, utf16 and utf32 are big endian.
+	charsetEncodings = map[string]encoding.Encoding{
+		"ascii":    charmap.Windows1252,
+		"big5":     traditionalchinese.Big5,
+		"cp1250":   charmap.Windows1250,
+		"cp1251":   charmap.Windows1251,
+		"cp1256":   charmap.Windows1256,
+		"cp1257":   charmap.Windows1257,
+		"cp850":    charmap.CodePage850,
+		"cp852":    charmap.CodePage852,
+		"cp866":    charmap.CodePage866,
+		"cp932":    japanese.ShiftJIS,
+		"eucjpms":  japanese.EUCJP,
+		"euckr":    korean.EUCKR,
+		"gb18030":  simplifiedchinese.GB18030,
+		"gb2312":   simplifiedchinese.GBK,
+		"gbk":      simplifiedchinese.GBK,
+		"greek":    charmap.ISO8859_7,
+		"hebrew":   charmap.ISO8859_8,
+		"koi8r":    charmap.KOI8R,
+		"koi8u":    charmap.KOI8U,
+		"latin1":   charmap.Windows1252,
+		"latin2":   charmap.ISO8859_2,
+		"latin5":   charmap.ISO8859_9,
+		"latin7":   charmap.ISO8859_13,
+		"macroman": charmap.Macintosh,
+		"sjis":     japanese.ShiftJIS,
+		"tis620":   charmap.Windows874,
+		"ucs2":     unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM),
+		"ujis":     japanese.EUCJP,
+		"utf16":    unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM),
+		"utf16le":  unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM),
+		"utf32":    utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM),
+		"utf8":     unicode.UTF8,
+		"utf8mb4":  unicode.UTF8,
+	}
+	// character sets golang.org/x/text has no encoding for and the ASCII
+	// characters they replace, their other ASCII values still convert
+	unsupportedCharsets = map[string]string{
+		"armscii8": emptyString,
+		"dec8":     emptyString,
+		"geostd8":  emptyString,
+		"hp8":      emptyString,
+		"keybcs2":  emptyString,
+		"macce":    emptyString,
+		"swe7":     "@[\\]^`{|}~",
+	}
+)
+
+// IsBinary reports whether values are raw bytes without a character set.
+func (cs *Charset) IsBinary() bool {
+	return cs.Name == binaryCharsetName
+}
+
+// IsPadSpace reports whether the collation ignores trailing spaces in
+// comparisons. MySQL 8.0 UCA 9.0.0 and MariaDB nopad collations do not.
+func (cs *Charset) IsPadSpace() bool {
+	return !strings.Contains(cs.Collate, "_0900_") && !strings.Contains(cs.Collate, "nopad")
+}
+
+// Decode converts a value stored in the character set to UTF-8. Binary
+// values are returned as is. Character sets without an Encoding convert
+// the ASCII values they keep and return UnsupportedCharsetErr for others.
+func (cs *Charset) Decode(b []byte) (string, error) {
+	if cs.IsBinary() {
+		return string(b), nil
+	}
+	if cs.Encoding == nil {
+		if !cs.keepsASCII(string(b)) {
+			return emptyString, UnsupportedCharsetErr
+		}
+		return string(b), nil
+	}
+	d, err := cs.Encoding.NewDecoder().Bytes(b)
+	if err != nil {
+		return emptyString, err
+	}
+	return string(d), nil
+}
+
+// DecodeChar converts a CHAR value to UTF-8 and strips the trailing pad
+// spaces the way the server does on retrieval.
+func (cs *Charset) DecodeChar(b []byte) (string, error) {
+	s, err := cs.Decode(b)
+	if err != nil || cs.IsBinary() {
+		return s, err
+	}
+	return strings.TrimRight(s, padSpace), nil
+}
+
+// Encode converts a UTF-8 string to the character set, with the limits
+// of Decode.
+func (cs *Charset) Encode(s string) ([]byte, error) {
+	if cs.IsBinary() {
+		return []byte(s), nil
+	}
+	if cs.Encoding == nil {
+		if !cs.keepsASCII(s) {
+			return nil, UnsupportedCharsetErr
+		}
+		return []byte(s), nil
+	}
+	return cs.Encoding.NewEncoder().Bytes([]byte(s))
+}
+
+// keepsASCII reports whether s is ASCII the character set does not
+// replace, when the character set has no Encoding.
+func (cs *Charset) keepsASCII(s string) bool {
+	replaced, ok := unsupportedCharsets[cs.Name]
+	if !ok {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 || strings.IndexByte(replaced, s[i]) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ColumnCharset returns the collation of the i-th column, or nil when
+// the column type has no character set.
+func (f *Frm) ColumnCharset(i int) *Charset {
+	c := &f.columns[i]
+	if !c.hasCharset() {
+		return nil
+	}
+	return c.charsetA()
+}
+
+// DecodeColumn converts a value of the i-th column to UTF-8. CHAR values
+// lose their pad spaces, BINARY values and non character columns are
+// returned as is.
+func (f *Frm) DecodeColumn(i int, b []byte) (string, error) {
+	cs := f.ColumnCharset(i)
+	if cs == nil {
+		return string(b), nil
+	}
+	if f.columns[i].fieldType == stringFieldType {
+		return cs.DecodeChar(b)
+	}
+	return cs.Decode(b)
+}
+
+// EncodeColumn converts a UTF-8 string to the character set of the i-th
+// column. The value is not padded.
+func (f *Frm) EncodeColumn(i int, s string) ([]byte, error) {
+	cs := f.ColumnCharset(i)
+	if cs == nil {
+		return []byte(s), nil
+	}
+	return cs.Encode(s)
+}
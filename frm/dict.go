@@ -0,0 +1,531 @@
+package frm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	WrongIBDataFileErr = errors.New("Wrong system tablespace file.")
+)
+
+const (
+	dictHdrPage    = 7
+	dictHdrTables  = filPageData + 32
+	dictHdrColumns = filPageData + 40
+	dictHdrIndexes = filPageData + 44
+	dictHdrFields  = filPageData + 48
+	filPageNext    = 12
+	indexPageType  = 17855
+	pageOldInf     = 101
+	pageOldSup     = 116
+	oldExtraBytes  = 6
+)
+
+const (
+	tempIndexPrefix   = "\xff"
+	genClustIndexName = "GEN_CLUST_INDEX"
+	ftsDocIdName      = "FTS_DOC_ID"
+)
+
+const (
+	clusteredDictIndex = 0x01
+	uniqueDictIndex    = 0x02
+	fullTextDictIndex  = 0x20
+	spatialDictIndex   = 0x40
+	virtualDictIndex   = 0x80
+)
+
+const (
+	intDictType       = 6
+	notNullDictFlag   = 0x0100
+	unsignedDictFlag  = 0x0200
+	mysqlTypeDictMask = 0xff
+	charsetDictMask   = 0x7fff
+)
+
+var (
+	ftsAuxTable  = regexp.MustCompile(`/FTS_[0-9a-fA-F]{16}_`)
+	dictIntTypes = map[uint8][2]int{
+		tinyFieldType:     {4, 3},
+		shortFieldType:    {6, 5},
+		int24FieldType:    {9, 8},
+		longFieldType:     {11, 10},
+		longLongFieldType: {20, 20},
+	}
+	dictBlobTypes = map[int]uint8{
+		1: tinyBlobFieldType,
+		2: blobFieldType,
+		3: mediumBlobFieldType,
+		4: longBlobFieldType,
+	}
+	decimalDigitBytes = [10]int{0, 1, 1, 2, 2, 3, 3, 4, 4, 4}
+)
+
+// DictTable is a table definition reconstructed from the InnoDB data
+// dictionary. Lost lists the parts of the definition that the
+// dictionary does not keep. Temp marks a table ALTER TABLE left behind,
+// named as its files ("test/#sql-ib42-123").
+type DictTable struct {
+	Name  string
+	Space uint32
+	Frm   *Frm
+	Lost  []string
+	Temp  bool
+	id    uint64
+}
+
+type dictColumn struct {
+	name    string
+	mtype   uint32
+	prtype  uint32
+	length  uint32
+	virtual bool
+}
+
+type dictIndex struct {
+	name   string
+	id     uint64
+	typ    uint32
+	fields []dictField
+}
+
+type dictField struct {
+	name   string
+	prefix int
+}
+
+// tablespace reads the pages of an InnoDB tablespace when they are
+// needed, so that large files are not loaded in memory. wrong is the
+// error returned for a malformed file.
+type tablespace struct {
+	r        io.ReaderAt
+	pageSize int
+	flags    uint32
+	wrong    error
+}
+
+// NewDict reconstructs the definitions of all user tables stored in
+// SYS_TABLES, SYS_COLUMNS, SYS_INDEXES and SYS_FIELDS of an ibdata file,
+// the temporary tables of ALTER TABLE included.
+func NewDict(path string) ([]*DictTable, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	ts, err := newTablespace(file, WrongIBDataFileErr)
+	if err != nil {
+		return nil, err
+	}
+	hdr, err := ts.page(dictHdrPage)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := ts.readSysTables(binary.BigEndian.Uint32(hdr[dictHdrTables:]))
+	if err != nil {
+		return nil, err
+	}
+	columns, err := ts.readSysColumns(binary.BigEndian.Uint32(hdr[dictHdrColumns:]))
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := ts.readSysIndexes(binary.BigEndian.Uint32(hdr[dictHdrIndexes:]))
+	if err != nil {
+		return nil, err
+	}
+	fields, err := ts.readSysFields(binary.BigEndian.Uint32(hdr[dictHdrFields:]))
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tables {
+		idx := indexes[t.id]
+		for i := range idx {
+			idx[i].fields = fields[idx[i].id]
+		}
+		t.Frm = &Frm{fileType: tableFileType, engineName: innoDBEngine}
+		t.readDict(columns[t.id], idx)
+	}
+	return tables, nil
+}
+
+// WriteCreateTable writes the reconstructed CREATE TABLE statement
+// preceded by comments listing the information that was lost.
+func (t *DictTable) WriteCreateTable(w io.Writer) {
+	for _, s := range t.Lost {
+		writeString(w, "-- ")
+		writeString(w, strings.Replace(s, "\n", " ", -1))
+		writeString(w, "\n")
+	}
+	name := t.Name
+	if i := strings.IndexByte(name, '/'); i >= 0 {
+		name = name[(i + 1):]
+	}
+	t.Frm.WriteCreateTable(w, name)
+}
+
+// newTablespace reads the flags of an uncompressed and unencrypted
+// tablespace from the header of its first page.
+func newTablespace(r io.ReaderAt, wrong error) (*tablespace, error) {
+	head := make([]byte, fspSpaceFlags+4)
+	if n, _ := r.ReadAt(head, 0); n < len(head) {
+		return nil, wrong
+	}
+	flags := binary.BigEndian.Uint32(head[fspSpaceFlags:])
+	if (flags>>zipSsizeShift)&zipSsizeMask != 0 || (flags&encryptionFlag) != 0 {
+		return nil, wrong
+	}
+	ts := &tablespace{r: r, pageSize: defaultPageSize, flags: flags, wrong: wrong}
+	if ssize := (flags >> pageSsizeShift) & pageSsizeMask; ssize != 0 {
+		ts.pageSize = 512 << ssize
+	}
+	return ts, nil
+}
+
+func (ts *tablespace) page(n uint32) ([]byte, error) {
+	page := make([]byte, ts.pageSize)
+	if k, _ := ts.r.ReadAt(page, int64(n)*int64(ts.pageSize)); k < len(page) {
+		return nil, ts.wrong
+	}
+	return page, nil
+}
+
+// scan calls fn with the fields of every live leaf record of the
+// REDUNDANT format index rooted at page root.
+func (ts *tablespace) scan(root uint32, fn func([][]byte) error) error {
+	pageNo := root
+	for {
+		page, err := ts.page(pageNo)
+		if err != nil {
+			return err
+		}
+		if binary.BigEndian.Uint16(page[filPageType:]) != indexPageType {
+			return WrongIBDataFileErr
+		}
+		if binary.BigEndian.Uint16(page[pageLevel:]) == 0 {
+			break
+		}
+		origin := int(binary.BigEndian.Uint16(page[(pageOldInf - 2):]))
+		f, err := oldRecordFields(page, origin)
+		if err != nil {
+			return err
+		}
+		last := f[len(f)-1]
+		if len(last) < 4 {
+			return WrongIBDataFileErr
+		}
+		pageNo = binary.BigEndian.Uint32(last[(len(last) - 4):])
+	}
+	for pageNo != filNull {
+		page, err := ts.page(pageNo)
+		if err != nil {
+			return err
+		}
+		n := 0
+		origin := int(binary.BigEndian.Uint16(page[(pageOldInf - 2):]))
+		for origin != pageOldSup {
+			n++
+			if origin <= pageOldSup || origin >= ts.pageSize || n > ts.pageSize {
+				return WrongIBDataFileErr
+			}
+			if (page[origin-oldExtraBytes] & recordDeletedFlag) == 0 {
+				f, err := oldRecordFields(page, origin)
+				if err != nil {
+					return err
+				}
+				if err = fn(f); err != nil {
+					return err
+				}
+			}
+			origin = int(binary.BigEndian.Uint16(page[(origin - 2):]))
+		}
+		pageNo = binary.BigEndian.Uint32(page[filPageNext:])
+	}
+	return nil
+}
+
+func oldRecordFields(page []byte, origin int) ([][]byte, error) {
+	numFields := int(binary.BigEndian.Uint16(page[(origin-4):])>>1) & 0x3ff
+	short := (page[origin-3] & 0x01) != 0
+	fields := make([][]byte, numFields)
+	start := 0
+	for i := 0; i < numFields; i++ {
+		end, null := 0, false
+		if short {
+			b := int(page[origin-oldExtraBytes-1-i])
+			end, null = b&0x7f, (b&0x80) != 0
+		} else {
+			b := int(binary.BigEndian.Uint16(page[(origin - oldExtraBytes - 2*(i+1)):]))
+			end, null = b&0x3fff, (b&0x8000) != 0
+		}
+		if end < start || origin+end > len(page) {
+			return nil, WrongIBDataFileErr
+		}
+		if !null {
+			fields[i] = page[(origin + start):(origin + end)]
+		}
+		start = end
+	}
+	return fields, nil
+}
+
+func dictUint32(f []byte) uint32 {
+	if len(f) != 4 {
+		return 0
+	}
+	return binary.BigEndian.Uint32(f)
+}
+
+func dictUint64(f []byte) uint64 {
+	if len(f) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(f)
+}
+
+func (ts *tablespace) readSysTables(root uint32) ([]*DictTable, error) {
+	tables := make([]*DictTable, 0)
+	seen := make(map[string]bool)
+	err := ts.scan(root, func(f [][]byte) error {
+		if len(f) < 10 {
+			return WrongIBDataFileErr
+		}
+		name := string(f[0])
+		i := strings.IndexByte(name, '/')
+		if i < 0 || ftsAuxTable.MatchString(name) {
+			return nil
+		}
+		db, err := DecodeFilename(name[:i])
+		if err != nil {
+			return err
+		}
+		tf, err := ParseTableFile(name[(i + 1):])
+		if err != nil {
+			return err
+		}
+		name = db + "/" + tf.Table
+		if seen[name] {
+			return nil
+		}
+		seen[name] = true
+		t := &DictTable{Name: name, Temp: tf.Temp, id: dictUint64(f[3]), Space: dictUint32(f[9])}
+		if tf.Partition != emptyString {
+			t.Lost = append(t.Lost, "partitioning, written from the first partition")
+		}
+		tables = append(tables, t)
+		return nil
+	})
+	return tables, err
+}
+
+func (ts *tablespace) readSysColumns(root uint32) (map[uint64][]dictColumn, error) {
+	columns := make(map[uint64][]dictColumn)
+	err := ts.scan(root, func(f [][]byte) error {
+		if len(f) < 9 {
+			return WrongIBDataFileErr
+		}
+		id := dictUint64(f[0])
+		c := dictColumn{
+			name:    string(f[4]),
+			mtype:   dictUint32(f[5]),
+			prtype:  dictUint32(f[6]),
+			length:  dictUint32(f[7]),
+			virtual: dictUint32(f[1]) > 0xffff,
+		}
+		columns[id] = append(columns[id], c)
+		return nil
+	})
+	return columns, err
+}
+
+func (ts *tablespace) readSysIndexes(root uint32) (map[uint64][]dictIndex, error) {
+	indexes := make(map[uint64][]dictIndex)
+	err := ts.scan(root, func(f [][]byte) error {
+		if len(f) < 7 {
+			return WrongIBDataFileErr
+		}
+		id := dictUint64(f[0])
+		i := dictIndex{id: dictUint64(f[1]), name: string(f[4]), typ: dictUint32(f[6])}
+		indexes[id] = append(indexes[id], i)
+		return nil
+	})
+	return indexes, err
+}
+
+func (ts *tablespace) readSysFields(root uint32) (map[uint64][]dictField, error) {
+	fields := make(map[uint64][]dictField)
+	err := ts.scan(root, func(f [][]byte) error {
+		if len(f) < 5 {
+			return WrongIBDataFileErr
+		}
+		id := dictUint64(f[0])
+		pos := dictUint32(f[1])
+		df := dictField{name: string(f[4])}
+		if len(fields[id]) == 0 || pos > 0xffff {
+			df.prefix = int(pos & 0xffff)
+		}
+		fields[id] = append(fields[id], df)
+		return nil
+	})
+	return fields, err
+}
+
+func (t *DictTable) readDict(columns []dictColumn, indexes []dictIndex) {
+	t.Lost = append(t.Lost, "default values, comments, AUTO_INCREMENT, integer display widths and table options are not stored in the InnoDB dictionary")
+	f := t.Frm
+	names := make(map[string]int)
+	hidden := make(map[string]bool)
+	f.columns = make([]column, 0, len(columns))
+	for i := range columns {
+		dc := &columns[i]
+		if dc.virtual {
+			t.Lost = append(t.Lost, fmt.Sprintf("column %s: virtual column dropped", QuoteIdent(dc.name)))
+			continue
+		}
+		// the document id InnoDB adds to a table with a FULLTEXT key has
+		// no MySQL type, a FTS_DOC_ID column of the table has one
+		if dc.name == ftsDocIdName && (dc.prtype&mysqlTypeDictMask) == 0 {
+			hidden[dc.name] = true
+			continue
+		}
+		c := column{name: dc.name}
+		t.readDictColumn(&c, dc)
+		names[c.name] = len(f.columns)
+		f.columns = append(f.columns, c)
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return (indexes[i].typ & clusteredDictIndex) > (indexes[j].typ & clusteredDictIndex)
+	})
+	f.keys = make([]key, 0, len(indexes))
+	for i := range indexes {
+		di := &indexes[i]
+		if strings.HasPrefix(di.name, tempIndexPrefix) {
+			continue
+		}
+		// the row id InnoDB adds to a table without a unique key; a
+		// UNIQUE NOT NULL key promoted to the clustered index keeps its
+		// name and is written as UNIQUE
+		if (di.typ&clusteredDictIndex) != 0 && di.name == genClustIndexName {
+			continue
+		}
+		if len(di.fields) == 1 && hidden[di.fields[0].name] {
+			continue
+		}
+		k := key{name: di.name}
+		if (di.typ & (clusteredDictIndex | uniqueDictIndex)) == 0 {
+			k.flags |= allowDupsKeyFlag
+		}
+		switch {
+		case (di.typ & fullTextDictIndex) != 0:
+			k.flags |= fullTextKeyFlag
+			k.algorithm = fullTextKeyAlgo
+		case (di.typ & spatialDictIndex) != 0:
+			k.flags |= spatialKeyFlag
+			k.algorithm = rTreeKeyAlgo
+		}
+		for _, df := range di.fields {
+			n, ok := names[df.name]
+			if !ok {
+				t.Lost = append(t.Lost, fmt.Sprintf("key %s: unknown column %s dropped", QuoteIdent(di.name), QuoteIdent(df.name)))
+				continue
+			}
+			length := df.prefix
+			if length == 0 {
+				length = int(f.columns[n].fieldLength)
+			}
+			k.parts = append(k.parts, part{fieldNum: uint16(n + 1), length: uint16(length)})
+		}
+		k.numParts = uint8(len(k.parts))
+		f.keys = append(f.keys, k)
+	}
+}
+
+func (t *DictTable) readDictColumn(c *column, dc *dictColumn) {
+	c.fieldType = uint8(dc.prtype & mysqlTypeDictMask)
+	c.fieldLength = uint16(dc.length)
+	cs := int(dc.prtype>>16) & charsetDictMask
+	c.charset = uint8(cs)
+	c.charsetLow = uint8(cs >> 8)
+	if (dc.prtype & notNullDictFlag) == 0 {
+		c.flags |= nullableFieldFlag
+	}
+	unsigned := (dc.prtype & unsignedDictFlag) != 0
+	if !unsigned {
+		c.flags |= signedFieldFlag
+	}
+	if w, ok := dictIntTypes[c.fieldType]; ok {
+		if unsigned {
+			c.fieldLength = uint16(w[1])
+		} else {
+			c.fieldLength = uint16(w[0])
+		}
+		return
+	}
+	switch c.fieldType {
+	case stringFieldType:
+		if dc.mtype == intDictType {
+			c.fieldType = tinyFieldType
+			c.fieldLength = 3
+			if dc.length > 1 {
+				c.fieldType = shortFieldType
+				c.fieldLength = 5
+			}
+			c.flags &^= signedFieldFlag
+			t.Lost = append(t.Lost, fmt.Sprintf("column %s: ENUM/SET values, written as integer", QuoteIdent(c.name)))
+			return
+		}
+		t.checkCharset(c)
+	case varCharFieldType:
+		t.checkCharset(c)
+	case blobFieldType:
+		c.fieldType = dictBlobTypes[int(dc.length)]
+		if c.fieldType == 0 {
+			c.fieldType = longBlobFieldType
+		}
+		c.fieldLength = 0
+		t.checkCharset(c)
+	case geometryFieldType:
+		c.charset = geometryGeomType
+		c.charsetLow = 0
+		t.Lost = append(t.Lost, fmt.Sprintf("column %s: geometry subtype, written as GEOMETRY", QuoteIdent(c.name)))
+	case newDecimalFieldType:
+		digits := 0
+		for n := int(dc.length); n > 0; {
+			if n >= 4 {
+				digits += 9
+				n -= 4
+				continue
+			}
+			for d := 9; d > 0; d-- {
+				if decimalDigitBytes[d] == n {
+					digits += d
+					break
+				}
+			}
+			n = 0
+		}
+		c.fieldLength = uint16(digits)
+		if !unsigned {
+			c.fieldLength++
+		}
+		t.Lost = append(t.Lost, fmt.Sprintf("column %s: DECIMAL precision and scale, written as DECIMAL(%d,0)", QuoteIdent(c.name), digits))
+	}
+}
+
+func (t *DictTable) checkCharset(c *column) {
+	cn := c.charsetNum()
+	if cn == binaryCharset {
+		return
+	}
+	if _, ok := charsets[cn]; !ok {
+		c.charset = binaryCharset
+		c.charsetLow = 0
+		t.Lost = append(t.Lost, fmt.Sprintf("column %s: unknown charset %d, written as binary", QuoteIdent(c.name), cn))
+	}
+}
@@ -0,0 +1,328 @@
+package frm
+
+import (
+	"html"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DocFormat is the markup of generated documentation.
+type DocFormat int
+
+const (
+	Markdown DocFormat = iota
+	HTML
+)
+
+const (
+	indexPage = "index"
+	tablesDir = "tables"
+)
+
+var (
+	sizeUnits        = []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	markdownReplacer = strings.NewReplacer(
+		"\\", "\\\\",
+		"|", "\\|",
+		"*", "\\*",
+		"_", "\\_",
+		"`", "\\`",
+		"<", "&lt;",
+		"\r\n", "<br>",
+		"\n", "<br>",
+	)
+)
+
+// TableDoc is the documentation of a table. Size is the total size of
+// the data and index files of the table and its partitions. Error tells
+// why the .frm file of a table could not be read, only Name and Size
+// are set then.
+type TableDoc struct {
+	Name    string
+	Engine  string
+	Comment string
+	Size    int64
+	Columns []Column
+	Keys    []Key
+	Error   string
+}
+
+func (format DocFormat) ext() string {
+	if format == HTML {
+		return ".html"
+	}
+	return ".md"
+}
+
+func (format DocFormat) escape(s string) string {
+	if format == HTML {
+		return strings.Replace(html.EscapeString(s), "\n", "<br>", -1)
+	}
+	return markdownReplacer.Replace(s)
+}
+
+// formatSize returns a size in bytes in binary units.
+func formatSize(n int64) string {
+	if n < 1024 {
+		return strconv.FormatInt(n, 10) + " " + sizeUnits[0]
+	}
+	f := float64(n)
+	i := 0
+	for f >= 1024 && i < len(sizeUnits)-1 {
+		f /= 1024
+		i++
+	}
+	return strconv.FormatFloat(f, 'f', 1, 64) + " " + sizeUnits[i]
+}
+
+// ReadDocs reads the documentation of the tables of the database
+// directory dir, sorted by name.
+func ReadDocs(dir string) ([]*TableDoc, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]int64)
+	for _, file := range files {
+		tf, err := ParseTableFile(file.Name())
+		if err != nil || tf.Temp || tf.Ext == frmExt {
+			continue
+		}
+		sizes[tf.Table] += file.Size()
+	}
+	docs := make([]*TableDoc, 0)
+	err = readDir(dir, func(table string, f *Frm) {
+		docs = append(docs, &TableDoc{
+			Name:    table,
+			Engine:  f.Engine(),
+			Comment: f.comment,
+			Size:    sizes[table],
+			Columns: f.Columns(),
+			Keys:    f.Keys(),
+		})
+	}, func(table string, err error) {
+		docs = append(docs, &TableDoc{Name: table, Size: sizes[table], Error: err.Error()})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+	return docs, nil
+}
+
+// docWriter writes the rows of a document in one markup.
+type docWriter struct {
+	w      io.Writer
+	format DocFormat
+}
+
+func (dw *docWriter) begin(title string) {
+	if dw.format == HTML {
+		writeString(dw.w, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>")
+		writeString(dw.w, dw.format.escape(title))
+		writeString(dw.w, "</title>\n</head>\n<body>\n")
+	}
+	dw.heading(1, title)
+}
+
+func (dw *docWriter) end() {
+	if dw.format == HTML {
+		writeString(dw.w, "</body>\n</html>\n")
+	}
+}
+
+func (dw *docWriter) heading(level int, s string) {
+	if dw.format == HTML {
+		h := "h" + strconv.Itoa(level)
+		writeString(dw.w, "<"+h+">"+dw.format.escape(s)+"</"+h+">\n")
+		return
+	}
+	writeString(dw.w, strings.Repeat("#", level)+" "+dw.format.escape(s)+"\n\n")
+}
+
+func (dw *docWriter) paragraph(s string) {
+	if dw.format == HTML {
+		writeString(dw.w, "<p>"+s+"</p>\n")
+		return
+	}
+	writeString(dw.w, s+"\n\n")
+}
+
+func (dw *docWriter) link(text, href string) string {
+	if dw.format == HTML {
+		return "<a href=\"" + html.EscapeString(href) + "\">" + dw.format.escape(text) + "</a>"
+	}
+	return "[" + dw.format.escape(text) + "](" + strings.Replace(href, " ", "%20", -1) + ")"
+}
+
+// table writes the cells, which are already escaped.
+func (dw *docWriter) table(header []string, rows [][]string) {
+	if dw.format == HTML {
+		writeString(dw.w, "<table>\n<tr>")
+		for _, s := range header {
+			writeString(dw.w, "<th>"+s+"</th>")
+		}
+		writeString(dw.w, "</tr>\n")
+		for _, row := range rows {
+			writeString(dw.w, "<tr>")
+			for _, s := range row {
+				writeString(dw.w, "<td>"+s+"</td>")
+			}
+			writeString(dw.w, "</tr>\n")
+		}
+		writeString(dw.w, "</table>\n")
+		return
+	}
+	writeString(dw.w, "| "+strings.Join(header, " | ")+" |\n")
+	writeString(dw.w, strings.Repeat("| --- ", len(header))+"|\n")
+	for _, row := range rows {
+		writeString(dw.w, "| "+strings.Join(row, " | ")+" |\n")
+	}
+	writeString(dw.w, "\n")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "YES"
+	}
+	return "NO"
+}
+
+// WriteTableDoc writes the page of a table.
+func WriteTableDoc(w io.Writer, d *TableDoc, format DocFormat) {
+	dw := &docWriter{w: w, format: format}
+	dw.begin(d.Name)
+	if d.Error != emptyString {
+		dw.paragraph(format.escape("The .frm file cannot be read: " + d.Error))
+		dw.end()
+		return
+	}
+	if d.Comment != emptyString {
+		dw.paragraph(format.escape(d.Comment))
+	}
+	dw.paragraph(format.escape("Engine " + d.Engine + ", " + formatSize(d.Size)))
+	dw.heading(2, "Columns")
+	rows := make([][]string, len(d.Columns))
+	for i, c := range d.Columns {
+		def := emptyString
+		switch {
+		case c.HasDefault:
+			def = c.Default
+		case c.Nullable:
+			def = "NULL"
+		}
+		extra := emptyString
+		if c.AutoIncrement {
+			extra = "auto_increment"
+		}
+		collation := emptyString
+		if c.Charset != nil {
+			collation = c.Charset.Collate
+		}
+		rows[i] = []string{
+			format.escape(c.Name),
+			format.escape(c.Type),
+			format.escape(collation),
+			yesNo(c.Nullable),
+			format.escape(def),
+			format.escape(extra),
+			format.escape(c.Comment),
+		}
+	}
+	dw.table([]string{"Column", "Type", "Collation", "Null", "Default", "Extra", "Comment"}, rows)
+	if len(d.Keys) > 0 {
+		dw.heading(2, "Indexes")
+		rows = make([][]string, len(d.Keys))
+		for i, k := range d.Keys {
+			parts := make([]string, len(k.Parts))
+			for j, p := range k.Parts {
+				s := p.Column
+				if p.Expr != emptyString {
+					s = "(" + p.Expr + ")"
+				}
+				if p.Length > 0 {
+					s += "(" + strconv.Itoa(p.Length) + ")"
+				}
+				if p.Desc {
+					s += " DESC"
+				}
+				parts[j] = s
+			}
+			rows[i] = []string{
+				format.escape(k.Name),
+				format.escape(string(k.Type)),
+				format.escape(strings.Join(parts, ", ")),
+				format.escape(k.Comment),
+			}
+		}
+		dw.table([]string{"Index", "Type", "Columns", "Comment"}, rows)
+	}
+	dw.end()
+}
+
+// WriteIndexDoc writes the page listing the tables of a database with
+// links to their pages.
+func WriteIndexDoc(w io.Writer, database string, docs []*TableDoc, format DocFormat) {
+	dw := &docWriter{w: w, format: format}
+	dw.begin(database)
+	rows := make([][]string, len(docs))
+	total := int64(0)
+	for i, d := range docs {
+		comment := d.Comment
+		if d.Error != emptyString {
+			comment = "The .frm file cannot be read"
+		}
+		rows[i] = []string{
+			dw.link(d.Name, tablesDir+"/"+EncodeFilename(d.Name)+format.ext()),
+			format.escape(d.Engine),
+			strconv.Itoa(len(d.Columns)),
+			formatSize(d.Size),
+			format.escape(comment),
+		}
+		total += d.Size
+	}
+	dw.table([]string{"Table", "Engine", "Columns", "Size", "Comment"}, rows)
+	dw.paragraph(format.escape(strconv.Itoa(len(docs)) + " tables, " + formatSize(total)))
+	dw.end()
+}
+
+// WriteDocs writes the documentation of the tables of the database
+// directory dir to outDir: an index page and a page per table in the
+// tables directory, named like the table files.
+func WriteDocs(dir, database, outDir string, format DocFormat) error {
+	docs, err := ReadDocs(dir)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Join(outDir, tablesDir), 0755); err != nil {
+		return err
+	}
+	if err = writeDocFile(filepath.Join(outDir, indexPage+format.ext()), func(w io.Writer) {
+		WriteIndexDoc(w, database, docs, format)
+	}); err != nil {
+		return err
+	}
+	for _, d := range docs {
+		d := d
+		if err = writeDocFile(filepath.Join(outDir, tablesDir, EncodeFilename(d.Name)+format.ext()), func(w io.Writer) {
+			WriteTableDoc(w, d, format)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeDocFile(path string, fn func(w io.Writer)) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	fn(file)
+	return file.Close()
+}
@@ -0,0 +1,251 @@
+package frm
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	WrongFilenameErr = errors.New("Wrong encoded file name.")
+)
+
+const (
+	filenameEscape     = '@'
+	partitionSep       = "#P#"
+	subpartitionSep    = "#SP#"
+	tempTablePrefix    = "#sql"
+	filenameCodeLength = 80
+	filenameCodeBase   = 0x30
+)
+
+// filenameCodes holds the two character codes MySQL uses for letters.
+// Those of the Latin-1 supplement are listed, the others are laid out
+// by filenameBlocks. Letters without such a code are encoded in the four
+// hex digit form, which the server decodes as well.
+var (
+	filenameCodes = map[rune]int{
+		0x00c0: 0x17, 0x00c1: 0x18, 0x00c2: 0x19, 0x00c3: 0x1a, 0x00c4: 0x1b,
+		0x00c5: 0x1c, 0x00c6: 0x1d, 0x00c7: 0x1e, 0x00c8: 0x1f, 0x00c9: 0x20,
+		0x00ca: 0x21, 0x00cb: 0x22, 0x00cc: 0x23, 0x00cd: 0x24, 0x00ce: 0x25,
+		0x00cf: 0x26, 0x00d0: 0x27, 0x00d1: 0x28, 0x00d2: 0x29, 0x00d3: 0x2a,
+		0x00d4: 0x67, 0x00d5: 0x68, 0x00d6: 0x69, 0x00d8: 0x6b, 0x00d9: 0x6c,
+		0x00da: 0x6d, 0x00db: 0x6e, 0x00dc: 0x6f, 0x00dd: 0x70, 0x00de: 0x71,
+		0x0178: 0x72, 0x00df: 0x8a,
+		0x00e0: 0x37, 0x00e1: 0x38, 0x00e2: 0x39, 0x00e3: 0x3a, 0x00e4: 0x3b,
+		0x00e5: 0x3c, 0x00e6: 0x3d, 0x00e7: 0x3e, 0x00e8: 0x3f, 0x00e9: 0x40,
+		0x00ea: 0x41, 0x00eb: 0x42, 0x00ec: 0x43, 0x00ed: 0x44, 0x00ee: 0x45,
+		0x00ef: 0x46, 0x00f0: 0x47, 0x00f1: 0x48, 0x00f2: 0x49, 0x00f3: 0x4a,
+		0x00f4: 0x87, 0x00f5: 0x88, 0x00f6: 0x89, 0x00f8: 0x8b, 0x00f9: 0x8c,
+		0x00fa: 0x8d, 0x00fb: 0x8e, 0x00fc: 0x8f, 0x00fd: 0x90, 0x00fe: 0x91,
+		0x00ff: 0x92,
+	}
+	filenameRunes = make(map[int]rune)
+	// the blocks of MySQL's table, Latin Extended-A after the 32 codes of
+	// the Latin-1 supplement
+	filenameBlocks = []filenameBlock{
+		{first: 0x0100, last: 0x017f, letters: filenameLetters, rows: "01234", start: 32},
+		{first: 0x0370, last: 0x03ff, letters: filenameLetters, rows: "56789"},
+		{first: 0x0400, last: 0x052f, letters: filenameLetters, rows: "0123456", letterFirst: true},
+		{first: 0x0530, last: 0x058f, letters: filenameLetters, rows: "78", letterFirst: true},
+		{first: 0x2160, last: 0x217f, letters: filenameLetters, rows: "9", letterFirst: true},
+		{first: 0x0180, last: 0x02af, letters: filenameLetters, rows: "abcdefghijk", letterFirst: true},
+		{first: 0x1e00, last: 0x1eff, letters: filenameLetters, rows: "lmnopqr", letterFirst: true},
+		{first: 0x1f00, last: 0x1fff, letters: filenameLetters, rows: "stuvwxyz", letterFirst: true},
+		{first: 0x24b0, last: 0x24ef, letters: filenameAlphabet, rows: "@"},
+		{first: 0xff20, last: 0xff5f, letters: filenameAlphabet, rows: "@", letterFirst: true},
+	}
+	// letters of these blocks added to Unicode after MySQL made its table,
+	// which has no codes for them
+	filenameNewLetters = [][2]rune{
+		{0x0237, 0x024f}, {0x0370, 0x0377}, {0x037b, 0x037d}, {0x03cf, 0x03cf},
+		{0x03fc, 0x03ff}, {0x04cf, 0x04cf}, {0x04f6, 0x04f7}, {0x04fa, 0x04ff},
+		{0x0510, 0x052f}, {0x0560, 0x0560}, {0x0588, 0x0588}, {0x1e9c, 0x1e9f},
+		{0x1efa, 0x1eff},
+	}
+)
+
+const (
+	filenameLetters  = "ghijklmnopqrstuvwxyz"
+	filenameAlphabet = "abcdefghijklmnopqrstuvwxyz"
+)
+
+// filenameBlock is a range of letters with two character codes. Each
+// lowercase letter, in order, takes the next code: one of letters in
+// turn, in the row of the next character of rows once they run out.
+// Its uppercase letter takes the same code with the letter uppercase.
+// The letter is the first character of the code when letterFirst is set
+// and the row is otherwise. The first start codes are taken already.
+type filenameBlock struct {
+	first       rune
+	last        rune
+	letters     string
+	rows        string
+	letterFirst bool
+	start       int
+}
+
+func (b *filenameBlock) has(r rune) bool {
+	if r < b.first || r > b.last {
+		return false
+	}
+	for _, n := range filenameNewLetters {
+		if r >= n[0] && r <= n[1] {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *filenameBlock) code(letter, row byte) int {
+	if b.letterFirst {
+		letter, row = row, letter
+	}
+	return (int(row)-filenameCodeBase)*filenameCodeLength + int(letter) - filenameCodeBase
+}
+
+func (b *filenameBlock) addCodes() {
+	n := b.start
+	for r := b.first; r <= b.last && n < len(b.letters)*len(b.rows); r++ {
+		upper := unicode.ToUpper(r)
+		if !b.has(r) || unicode.IsTitle(r) || (upper == r && !unicode.IsLower(r)) {
+			continue
+		}
+		letter, row := b.letters[n%len(b.letters)], b.rows[n/len(b.letters)]
+		filenameCodes[r] = b.code(letter, row)
+		if upper != r && b.has(upper) && unicode.ToLower(upper) == r {
+			filenameCodes[upper] = b.code(letter-'a'+'A', row)
+		}
+		n++
+	}
+}
+
+func init() {
+	for i := range filenameBlocks {
+		filenameBlocks[i].addCodes()
+	}
+	for r, code := range filenameCodes {
+		filenameRunes[code] = r
+	}
+}
+
+func filenameCodeChar(c int) bool {
+	return c >= filenameCodeBase && c < filenameCodeBase+filenameCodeLength
+}
+
+func filenameSafe(r rune) bool {
+	return (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+}
+
+// EncodeFilename converts an identifier to the form MySQL uses for
+// database and table file names.
+func EncodeFilename(s string) string {
+	b := &strings.Builder{}
+	for _, r := range s {
+		if filenameSafe(r) {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteByte(filenameEscape)
+		if code, ok := filenameCodes[r]; ok {
+			b.WriteByte(byte(code/filenameCodeLength + filenameCodeBase))
+			b.WriteByte(byte(code%filenameCodeLength + filenameCodeBase))
+			continue
+		}
+		if r > 0xffff {
+			r = utf8.RuneError
+		}
+		h := strconv.FormatInt(int64(r), 16)
+		b.WriteString(strings.Repeat("0", 4-len(h)))
+		b.WriteString(h)
+	}
+	return b.String()
+}
+
+// DecodeFilename converts a database or table file name back to the
+// identifier it was created from.
+func DecodeFilename(s string) (string, error) {
+	b := &strings.Builder{}
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c != filenameEscape {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		if i+4 < len(s) {
+			if n, err := strconv.ParseUint(s[(i+1):(i+5)], 16, 16); err == nil {
+				b.WriteRune(rune(n))
+				i += 5
+				continue
+			}
+		}
+		if i+2 < len(s) {
+			c1, c2 := int(s[i+1]), int(s[i+2])
+			if c1 == filenameEscape && c2 == filenameEscape {
+				b.WriteByte(0)
+				i += 3
+				continue
+			}
+			code := (c1-filenameCodeBase)*filenameCodeLength + c2 - filenameCodeBase
+			if r, ok := filenameRunes[code]; ok && filenameCodeChar(c1) && filenameCodeChar(c2) {
+				b.WriteRune(r)
+				i += 3
+				continue
+			}
+		}
+		return emptyString, WrongFilenameErr
+	}
+	return b.String(), nil
+}
+
+// TableFile is a file of a table data directory split into its parts.
+type TableFile struct {
+	Table        string
+	Partition    string
+	Subpartition string
+	Ext          string
+	Temp         bool
+}
+
+// ParseTableFile splits a file name like "t@002d1#P#p0.ibd" into the
+// decoded table, partition and subpartition names and the extension.
+// Temporary files left by ALTER TABLE ("#sql-...") are marked Temp and
+// their names are kept as is.
+func ParseTableFile(name string) (*TableFile, error) {
+	f := &TableFile{}
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		f.Ext = name[(i + 1):]
+		name = name[:i]
+	}
+	if strings.HasPrefix(name, tempTablePrefix) {
+		f.Table = name
+		f.Temp = true
+		return f, nil
+	}
+	upper := strings.ToUpper(name)
+	if i := strings.Index(upper, subpartitionSep); i >= 0 {
+		sub, err := DecodeFilename(name[(i + len(subpartitionSep)):])
+		if err != nil {
+			return nil, err
+		}
+		f.Subpartition = sub
+		name, upper = name[:i], upper[:i]
+	}
+	if i := strings.Index(upper, partitionSep); i >= 0 {
+		part, err := DecodeFilename(name[(i + len(partitionSep)):])
+		if err != nil {
+			return nil, err
+		}
+		f.Partition = part
+		name = name[:i]
+	}
+	table, err := DecodeFilename(name)
+	if err != nil {
+		return nil, err
+	}
+	f.Table = table
+	return f, nil
+}
@@ -0,0 +1,502 @@
+package frm
+
+import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FileClass is the kind of a file of a database directory.
+type FileClass string
+
+const (
+	TableDefinitionFile FileClass = "table definition"
+	TablespaceFile      FileClass = "tablespace"
+	TablespaceLinkFile  FileClass = "tablespace link"
+	ExportFile          FileClass = "export metadata"
+	EngineFile          FileClass = "engine data"
+	TriggerFile         FileClass = "trigger"
+	DatabaseOptionsFile FileClass = "database options"
+	TemporaryFile       FileClass = "temporary"
+	UnknownFile         FileClass = "unknown"
+)
+
+// Kinds of file problems.
+const (
+	LeftoverTemporary = "leftover temporary table"
+	MissingTablespace = "missing tablespace"
+	OrphanTablespace  = "orphaned tablespace"
+	OrphanExport      = "orphaned .cfg file"
+	ExportMismatch    = ".cfg file does not match"
+	SpaceIdMismatch   = "space id mismatch"
+	NotInDictionary   = "tablespace not in dictionary"
+	UnreadableFile    = "unreadable file"
+	WrongFileName     = "wrong file name"
+)
+
+const (
+	trgExt         = "trg"
+	trnExt         = "trn"
+	tempNamePrefix = "#mysql50#"
+	ibdataFile     = "ibdata1"
+)
+
+var (
+	// extensions of the files storage engines other than InnoDB keep next
+	// to the .frm file
+	engineExts = map[string]bool{
+		mydExt: true, myiExt: true, mrgExt: true, "CSV": true, "CSM": true,
+		"ARZ": true, "ARM": true, "par": true, "MAD": true, "MAI": true,
+	}
+)
+
+// DirFile is a classified file of a database directory. Space is the
+// space id of a tablespace.
+type DirFile struct {
+	Name  string
+	Class FileClass
+	Table string
+	Space uint32
+}
+
+// FileProblem is an inconsistency between the files of a table. Fix
+// lists the statements that clean it up, for the mysql client: SQL
+// statements and shell commands run with \!. Fix is empty when the
+// problem needs a decision.
+type FileProblem struct {
+	Kind    string
+	Table   string
+	Files   []string
+	Message string
+	Fix     []string
+}
+
+func (p FileProblem) String() string {
+	return p.Kind + ": " + strings.Join(p.Files, ", ") + ": " + p.Message
+}
+
+// FilesReport lists the files of a database directory and their
+// problems. Notes tell which checks were skipped and why.
+type FilesReport struct {
+	Database string
+	Dir      string
+	Files    []DirFile
+	Problems []FileProblem
+	Notes    []string
+}
+
+// tableFiles are the files of a table found in a database directory.
+type tableFiles struct {
+	frm *DirFile
+	ibd []*DirFile
+	isl bool
+	cfg *DirFile
+}
+
+type filesChecker struct {
+	report *FilesReport
+	dict   map[string]*DictTable
+	tables map[string]*tableFiles
+	temps  map[string][]string
+}
+
+// CheckFilesDataDir checks the files of every database in a data
+// directory. Space ids are compared with the dictionary of the ibdata1
+// file when it has one.
+func CheckFilesDataDir(dataDir string) ([]*FilesReport, error) {
+	dict, note := readDataDirDict(dataDir)
+	dirs, err := ioutil.ReadDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*FilesReport, 0)
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		database, err := DecodeFilename(dir.Name())
+		if err != nil {
+			reports = append(reports, &FilesReport{Database: dir.Name(), Dir: filepath.Join(dataDir, dir.Name()), Problems: []FileProblem{
+				{Kind: WrongFileName, Files: []string{dir.Name()}, Message: "the database is not checked"},
+			}})
+			continue
+		}
+		if systemDatabases[database] {
+			continue
+		}
+		r, err := CheckFilesDir(filepath.Join(dataDir, dir.Name()), database, dict)
+		if err != nil {
+			return nil, err
+		}
+		if note != emptyString {
+			r.Notes = append(r.Notes, note)
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+// CheckFilesDatabase checks the files of one database of a data
+// directory, as CheckFilesDataDir does.
+func CheckFilesDatabase(dataDir, database string) (*FilesReport, error) {
+	dict, note := readDataDirDict(dataDir)
+	r, err := CheckFilesDir(filepath.Join(dataDir, EncodeFilename(database)), database, dict)
+	if err != nil {
+		return nil, err
+	}
+	if note != emptyString {
+		r.Notes = append(r.Notes, note)
+	}
+	return r, nil
+}
+
+// readDataDirDict reads the dictionary of the ibdata1 file of a data
+// directory. Without one, the note tells why space ids are not checked.
+func readDataDirDict(dataDir string) ([]*DictTable, string) {
+	dict, err := NewDict(filepath.Join(dataDir, ibdataFile))
+	switch {
+	case os.IsNotExist(err):
+		return nil, "space ids not checked: no " + ibdataFile + " file"
+	case err != nil:
+		return nil, "space ids not checked: " + ibdataFile + ": " + err.Error()
+	}
+	return dict, emptyString
+}
+
+// CheckFilesDir classifies the files of the database directory dir and
+// checks that every table has its .frm file and tablespace, that .cfg
+// files belong to their tables and, with a dictionary, that the space
+// ids of the tablespaces match it. Leftovers of ALTER TABLE are reported
+// as well.
+func CheckFilesDir(dir, database string, dict []*DictTable) (*FilesReport, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	fc := &filesChecker{
+		report: &FilesReport{Database: database, Dir: dir, Files: make([]DirFile, 0, len(files)), Problems: make([]FileProblem, 0)},
+		tables: make(map[string]*tableFiles),
+		temps:  make(map[string][]string),
+	}
+	if dict != nil {
+		fc.dict = make(map[string]*DictTable)
+		for _, t := range dict {
+			fc.dict[t.Name] = t
+		}
+	}
+	for _, file := range files {
+		if !file.IsDir() {
+			fc.report.Files = append(fc.report.Files, fc.classify(file.Name()))
+		}
+	}
+	for i := range fc.report.Files {
+		fc.add(&fc.report.Files[i])
+	}
+	fc.checkTemps()
+	names := make([]string, 0, len(fc.tables))
+	for name := range fc.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fc.checkTable(name, fc.tables[name])
+	}
+	return fc.report, nil
+}
+
+func (fc *filesChecker) classify(name string) DirFile {
+	f := DirFile{Name: name, Class: UnknownFile}
+	if name == dbOptFile {
+		f.Class = DatabaseOptionsFile
+		return f
+	}
+	tf, err := ParseTableFile(name)
+	if err != nil {
+		fc.problem(WrongFileName, emptyString, "the file is not checked", nil, name)
+		return f
+	}
+	f.Table = tf.Table
+	switch {
+	case tf.Temp:
+		f.Class = TemporaryFile
+	case tf.Ext == frmExt:
+		f.Class = TableDefinitionFile
+	case tf.Ext == ibdExt:
+		f.Class = TablespaceFile
+		if space, err := readSpaceId(fc.path(name)); err == nil {
+			f.Space = space
+		} else {
+			fc.problem(UnreadableFile, f.Table, err.Error(), nil, name)
+		}
+	case tf.Ext == islExt:
+		f.Class = TablespaceLinkFile
+	case tf.Ext == cfgExt:
+		f.Class = ExportFile
+	case strings.EqualFold(tf.Ext, trgExt) || strings.EqualFold(tf.Ext, trnExt):
+		f.Class = TriggerFile
+	case engineExts[tf.Ext]:
+		f.Class = EngineFile
+	}
+	return f
+}
+
+func (fc *filesChecker) add(f *DirFile) {
+	if f.Class == TemporaryFile {
+		base := f.Name
+		if i := strings.LastIndexByte(base, '.'); i >= 0 {
+			base = base[:i]
+		}
+		fc.temps[base] = append(fc.temps[base], f.Name)
+		return
+	}
+	if f.Table == emptyString || f.Class == TriggerFile {
+		return
+	}
+	t := fc.tables[f.Table]
+	if t == nil {
+		t = &tableFiles{}
+		fc.tables[f.Table] = t
+	}
+	switch f.Class {
+	case TableDefinitionFile:
+		t.frm = f
+	case TablespaceFile:
+		t.ibd = append(t.ibd, f)
+	case TablespaceLinkFile:
+		t.isl = true
+	case ExportFile:
+		t.cfg = f
+	}
+}
+
+func (fc *filesChecker) path(name string) string {
+	return filepath.Join(fc.report.Dir, name)
+}
+
+func (fc *filesChecker) problem(kind, table, message string, fix []string, files ...string) {
+	fc.report.Problems = append(fc.report.Problems, FileProblem{
+		Kind: kind, Table: table, Files: files, Message: message, Fix: fix,
+	})
+}
+
+// checkTemps reports the files ALTER TABLE left behind. A temporary table
+// with a .frm file is dropped by the server. One the InnoDB dictionary
+// still has needs the .frm file of a table with the same columns and keys
+// to be dropped, the files of others are removed.
+func (fc *filesChecker) checkTemps() {
+	names := make([]string, 0, len(fc.temps))
+	for name := range fc.temps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		files := fc.temps[name]
+		sort.Strings(files)
+		hasFrm, hasIbd := false, false
+		for _, file := range files {
+			hasFrm = hasFrm || strings.HasSuffix(file, "."+frmExt)
+			hasIbd = hasIbd || strings.HasSuffix(file, "."+ibdExt)
+		}
+		drop := "DROP TABLE " + QuoteIdent(fc.report.Database) + "." + QuoteIdent(tempNamePrefix+name) + ";"
+		message := "left by an interrupted ALTER TABLE"
+		fix := make([]string, 0, len(files))
+		dt := fc.dict[fc.report.Database+"/"+name]
+		switch {
+		case hasFrm:
+			fix = append(fix, drop)
+		case dt != nil:
+			if frm := fc.sameColumns(dt); frm != emptyString {
+				fix = append(fix, "\\! cp -- "+shellQuote(fc.path(frm))+" "+shellQuote(fc.path(name+"."+frmExt)), drop)
+			} else {
+				message += ", still in the dictionary: copy the .frm file of a table with the same columns and keys to " +
+					name + "." + frmExt + " and drop it"
+			}
+		case hasIbd && fc.dict == nil:
+			message += ", the dictionary may still have it: no " + ibdataFile + " file to check"
+		default:
+			for _, file := range files {
+				fix = append(fix, "\\! rm -- "+shellQuote(fc.path(file)))
+			}
+		}
+		fc.problem(LeftoverTemporary, name, message, fix, files...)
+	}
+}
+
+// sameColumns returns the .frm file of a table of the directory with the
+// columns of a dictionary table, or an empty string.
+func (fc *filesChecker) sameColumns(dt *DictTable) string {
+	if dt.Frm == nil {
+		return emptyString
+	}
+	want := dt.Frm.Columns()
+	names := make([]string, 0, len(fc.tables))
+	for name, t := range fc.tables {
+		if t.frm != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var f *Frm
+		var err error
+		if perr := safely(func() { f, err = NewFrm(fc.path(fc.tables[name].frm.Name)) }); perr != nil || err != nil {
+			continue
+		}
+		columns := f.Columns()
+		same := len(columns) == len(want)
+		for i := 0; same && i < len(columns); i++ {
+			same = columns[i].Name == want[i].Name
+		}
+		if same {
+			return fc.tables[name].frm.Name
+		}
+	}
+	return emptyString
+}
+
+func (fc *filesChecker) checkTable(name string, t *tableFiles) {
+	table := QuoteIdent(fc.report.Database) + "." + QuoteIdent(name)
+	var f *Frm
+	if t.frm != nil {
+		var err error
+		if perr := safely(func() { f, err = NewFrm(fc.path(t.frm.Name)) }); perr != nil {
+			f, err = nil, perr
+		}
+		if err != nil && err != WrongFRMFileErr {
+			fc.problem(UnreadableFile, name, err.Error(), nil, t.frm.Name)
+		}
+	}
+	dt := fc.dict[fc.report.Database+"/"+name]
+	switch {
+	case t.frm == nil && len(t.ibd) > 0:
+		for _, ibd := range t.ibd {
+			if !hasSdi(fc.path(ibd.Name)) {
+				fc.problem(OrphanTablespace, name,
+					"no .frm file, recreate the table, DISCARD its TABLESPACE and IMPORT this file to keep the data",
+					nil, ibd.Name)
+			}
+		}
+	case f != nil && f.Engine() == innoDBEngine && len(t.ibd) == 0 && !t.isl:
+		if fc.dict == nil {
+			fc.problem(MissingTablespace, name, "no .ibd file, unless the table is in the system tablespace", nil, t.frm.Name)
+		} else if dt == nil || dt.Space != 0 {
+			fc.problem(MissingTablespace, name, "no .ibd file", []string{"DROP TABLE " + table + ";"}, t.frm.Name)
+		}
+	}
+	if t.frm != nil && fc.dict != nil && len(t.ibd) == 1 && t.ibd[0].Table == name {
+		fc.checkSpace(name, table, t.ibd[0], dt)
+	}
+	if t.cfg != nil {
+		fc.checkCfg(name, t, f)
+	}
+}
+
+// checkSpace compares the space id of a tablespace with the dictionary.
+// A tablespace copied from another server is imported again.
+func (fc *filesChecker) checkSpace(name, table string, ibd *DirFile, dt *DictTable) {
+	switch {
+	case dt == nil:
+		fc.problem(NotInDictionary, name, "the dictionary has no such table", nil, ibd.Name)
+	case dt.Space != ibd.Space:
+		path := shellQuote(fc.path(ibd.Name))
+		saved := shellQuote(fc.path(ibd.Name + ".saved"))
+		fc.problem(SpaceIdMismatch, name,
+			"space id "+strconv.Itoa(int(ibd.Space))+", the dictionary has "+strconv.Itoa(int(dt.Space)),
+			[]string{
+				"\\! mv -- " + path + " " + saved,
+				"ALTER TABLE " + table + " DISCARD TABLESPACE;",
+				"\\! mv -- " + saved + " " + path,
+				"ALTER TABLE " + table + " IMPORT TABLESPACE;",
+			}, ibd.Name)
+	}
+}
+
+// checkCfg checks that a .cfg file has a tablespace to import and that
+// it describes the table.
+func (fc *filesChecker) checkCfg(name string, t *tableFiles, f *Frm) {
+	remove := []string{"\\! rm -- " + shellQuote(fc.path(t.cfg.Name))}
+	if len(t.ibd) == 0 {
+		fc.problem(OrphanExport, name, "no tablespace to import", remove, t.cfg.Name)
+		return
+	}
+	cfg, err := readCfg(fc.path(t.cfg.Name))
+	if err != nil {
+		fc.problem(UnreadableFile, name, err.Error(), nil, t.cfg.Name)
+		return
+	}
+	cfgName := cfg.name
+	if i := strings.IndexByte(cfgName, '/'); i >= 0 {
+		cfgName = cfgName[(i + 1):]
+	}
+	if cfgTable, err := DecodeFilename(cfgName); err != nil || cfgTable != name {
+		fc.problem(ExportMismatch, name, "written for table "+cfg.name, remove, t.cfg.Name)
+		return
+	}
+	if f != nil && len(cfg.columns) != len(f.columns) {
+		fc.problem(ExportMismatch, name,
+			strconv.Itoa(len(cfg.columns))+" columns, the table has "+strconv.Itoa(len(f.columns)), remove, t.cfg.Name)
+	}
+}
+
+// readSpaceId returns the space id of a tablespace from the header of
+// its first page.
+func readSpaceId(path string) (uint32, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	head := make([]byte, fspSpaceFlags+4)
+	if _, err = io.ReadFull(file, head); err != nil {
+		return 0, WrongIBDFileErr
+	}
+	return binary.BigEndian.Uint32(head[filPageData:]), nil
+}
+
+// hasSdi reports whether a tablespace keeps its table definition, as
+// those of MySQL 8.0 do. Only the header of the first page is read.
+func hasSdi(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	ts, err := newTablespace(file, WrongIBDFileErr)
+	return err == nil && (ts.flags&sdiFlag) != 0
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", "'\\''", -1) + "'"
+}
+
+// WriteFilesReport writes the problems of the reports as comments. With
+// cleanup, each is followed by the statements that fix it.
+func WriteFilesReport(w io.Writer, reports []*FilesReport, cleanup bool) {
+	for _, r := range reports {
+		for _, note := range r.Notes {
+			writeString(w, "-- ")
+			writeString(w, QuoteIdent(r.Database))
+			writeSpace(w)
+			writeString(w, note)
+			writeString(w, "\n")
+		}
+		for _, p := range r.Problems {
+			writeString(w, "-- ")
+			writeString(w, QuoteIdent(r.Database))
+			writeSpace(w)
+			writeString(w, p.String())
+			writeString(w, "\n")
+			if cleanup {
+				for _, s := range p.Fix {
+					writeString(w, s)
+					writeString(w, "\n")
+				}
+			}
+		}
+	}
+}
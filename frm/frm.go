@@ -7,17 +7,26 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	frmExt = "frm"
 )
 
 var (
-	WrongFRMFileErr = errors.New("Wrong FRM file type.")
+	WrongFRMFileErr       = errors.New("Wrong FRM file type.")
+	UnsupportedFRMFileErr = errors.New("Unsupported FRM file version.")
 )
 
 type Frm struct {
 	fileType          uint16
 	version           uint8
 	legacyDbType      uint8
+	namesLength       uint16
 	ioSize            uint16
+	numNames          uint16
 	length            uint32
 	tmpKeyLength      uint16
 	recLength         uint16
@@ -39,6 +48,15 @@ type Frm struct {
 	extraRecBufLen    uint16
 	defaultPartDbType uint8
 	keyBlockSize      uint16
+	formPos           int
+	connectString     string
+	union             []MergeTable
+	insertMethod      string
+	dataDirectory     string
+	indexDirectory    string
+	engineName        string
+	partitionInfo     string
+	comment           string
 	columns           []column
 	keys              []key
 }
@@ -62,22 +80,73 @@ func NewFrm(path string) (*Frm, error) {
 	if frm.fileType != tableFileType {
 		return nil, WrongFRMFileErr
 	}
+	if frm.version < oldFrmVersion {
+		return nil, UnsupportedFRMFileErr
+	}
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
+	frm.readFormPos(data)
 	frm.readKeys(data)
 	frm.readColumns(data)
+	frm.readExtra(data)
+	frm.fixOldColumns()
+	if err = frm.checkCharsets(); err != nil {
+		return nil, err
+	}
+	if err = frm.readDefaults(data); err != nil {
+		return nil, err
+	}
+	if err = frm.readEngineFiles(path); err != nil {
+		return nil, err
+	}
 	return frm, nil
 }
 
+// readDir calls fn for every table of the database directory dir that
+// has a .frm file, and failed for the tables whose .frm file cannot be
+// read, damaged or of an unsupported version. A .frm file with a name
+// that cannot be decoded fails with its file name. Views and temporary
+// tables are skipped.
+func readDir(dir string, fn func(table string, f *Frm), failed func(table string, err error)) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		tf, err := ParseTableFile(file.Name())
+		if err != nil {
+			if filepath.Ext(file.Name()) == "."+frmExt {
+				failed(file.Name(), err)
+			}
+			continue
+		}
+		if tf.Ext != frmExt || tf.Temp {
+			continue
+		}
+		var f *Frm
+		if perr := safely(func() { f, err = NewFrm(filepath.Join(dir, file.Name())) }); perr != nil {
+			err = perr
+		}
+		switch {
+		case err == WrongFRMFileErr:
+		case err != nil:
+			failed(tf.Table, err)
+		default:
+			fn(tf.Table, f)
+		}
+	}
+	return nil
+}
+
 func (f *Frm) read(data []byte) {
 	f.fileType = binary.LittleEndian.Uint16(data[0:2])
 	f.version = data[2]
 	f.legacyDbType = data[3]
-	// skip 2
+	f.namesLength = binary.LittleEndian.Uint16(data[4:6])
 	f.ioSize = binary.LittleEndian.Uint16(data[6:8])
-	// skip 2
+	f.numNames = binary.LittleEndian.Uint16(data[8:10])
 	f.length = binary.LittleEndian.Uint32(data[10:14])
 	f.tmpKeyLength = binary.LittleEndian.Uint16(data[14:16])
 	f.recLength = binary.LittleEndian.Uint16(data[16:18])
@@ -94,8 +163,8 @@ func (f *Frm) read(data []byte) {
 	f.rowType = data[40]
 	f.charsetLow = data[41]
 	f.statSamplePages = binary.LittleEndian.Uint16(data[42:44])
-	f.statAutoRecalc = data[45]
-	// skip 2
+	f.statAutoRecalc = data[44]
+	// skip 3
 	f.keyLength = binary.LittleEndian.Uint32(data[47:51])
 	f.mySQLVersionId = binary.LittleEndian.Uint32(data[51:55])
 	f.extraSize = binary.LittleEndian.Uint32(data[55:59])
@@ -104,7 +173,27 @@ func (f *Frm) read(data []byte) {
 	f.keyBlockSize = binary.LittleEndian.Uint16(data[62:64])
 }
 
+// readFormPos reads the position of the form info, which follows the
+// form names after the header. Files of every release keep it there,
+// but older ones do not place the form info where columnsPos expects.
+func (f *Frm) readFormPos(data []byte) {
+	pos := frmStructSize + int(f.namesLength)
+	if f.numNames == 0 || pos+4 > len(data) {
+		return
+	}
+	f.formPos = int(binary.LittleEndian.Uint32(data[pos:(pos + 4)]))
+}
+
+// isPre50 reports whether the file was written before MySQL 5.0, which
+// marks its files and stores its version id.
+func (f *Frm) isPre50() bool {
+	return f.fileVersion < mySQL50FileVersion && f.mySQLVersionId == 0
+}
+
 func (f *Frm) columnsPos() int {
+	if f.formPos > 0 {
+		return f.formPos + formInfoSize
+	}
 	ioSize := int(f.ioSize)
 	offset := ioSize + int(f.tmpKeyLength) + int(f.recLength)
 	offset = ((offset / ioSize) + 1) * ioSize
@@ -119,6 +208,9 @@ func (f *Frm) readColumns(data []byte) {
 	data = data[f.columnsPos():]
 	numScreens := int(binary.LittleEndian.Uint16(data[0:2]))
 	numColumns := int(binary.LittleEndian.Uint16(data[2:4]))
+	namesLength := int(binary.LittleEndian.Uint16(data[12:14]))
+	numIntervals := int(binary.LittleEndian.Uint16(data[14:16]))
+	intervalsLength := int(binary.LittleEndian.Uint16(data[18:20]))
 	data = data[32:]
 	f.columns = make([]column, numColumns)
 	colNum := 0
@@ -137,6 +229,37 @@ func (f *Frm) readColumns(data []byte) {
 		f.columns[i].read(data)
 		data = data[columnStructSize:]
 	}
+	data = data[namesLength:]
+	intervals := readIntervals(data[:intervalsLength], numIntervals)
+	data = data[intervalsLength:]
+	for i := 0; i < numColumns; i++ {
+		c := &f.columns[i]
+		if n := int(c.intervalNr); n > 0 && n <= len(intervals) {
+			c.intervals = intervals[n-1]
+			if c.isWide() {
+				c.unhexIntervals()
+			}
+		}
+		n := int(c.comentLength)
+		c.comment = string(data[:n])
+		data = data[n:]
+	}
+}
+
+// readIntervals reads the ENUM and SET values. Each list starts with a
+// separator, which also ends every value, and the list ends with 0.
+func readIntervals(data []byte, num int) [][]string {
+	intervals := make([][]string, 0, num)
+	for i := 0; i < num && len(data) > 0; i++ {
+		j := bytes.IndexByte(data, 0)
+		if j < 1 {
+			break
+		}
+		values := strings.Split(string(data[1:j]), string(data[0:1]))
+		intervals = append(intervals, values[:(len(values)-1)])
+		data = data[(j + 1):]
+	}
+	return intervals
 }
 
 func (f *Frm) readKeys(data []byte) {
@@ -167,28 +290,179 @@ func (f *Frm) readKeys(data []byte) {
 		f.keys[i].name = string(data[:j])
 		data = data[(j + 1):]
 	}
+	if len(data) > 0 && data[0] == 0 {
+		data = data[1:]
+	}
+	for i := 0; i < numKeys; i++ {
+		k := &f.keys[i]
+		if (k.flags & usesCommentKeyFlag) == 0 {
+			continue
+		}
+		n := int(binary.LittleEndian.Uint16(data[0:2]))
+		k.comment = string(data[2:(2 + n)])
+		data = data[(2 + n):]
+	}
 }
 
-func (f *Frm) WriteCreateTable(w io.Writer, table string) {
-	io.WriteString(w, "CREATE TABLE")
-	writeSpace(w)
-	writeQuoted(w, table)
-	writeOpenParen(w)
-	l := len(f.columns)
-	for i := 0; i < l; i++ {
-		if i > 0 {
-			writeComma(w)
+func (f *Frm) recordPos() int {
+	if f.tmpKeyLength == 0xffff {
+		return int(f.ioSize) + int(f.keyLength)
+	}
+	return int(f.ioSize) + int(f.tmpKeyLength)
+}
+
+// readExtra reads the table comment and the extra segment stored after
+// the default record.
+func (f *Frm) readExtra(data []byte) {
+	form := data[(f.columnsPos() - formInfoSize):]
+	n := int(form[formCommentPos])
+	if n != longCommentLength {
+		f.comment = string(form[(formCommentPos + 1):(formCommentPos + 1 + n)])
+	}
+	pos := f.recordPos() + int(f.recLength)
+	end := pos + int(f.extraSize)
+	if f.extraSize == 0 || end > len(data) {
+		return
+	}
+	data = data[pos:end]
+	n = int(binary.LittleEndian.Uint16(data[0:2]))
+	f.connectString = string(data[2:(2 + n)])
+	data = data[(2 + n):]
+	if f.mySQLVersionId < engineNameVersionId {
+		// MySQL 5.0 keeps only the connect string
+		return
+	}
+	if len(data) > 2 {
+		n = int(binary.LittleEndian.Uint16(data[0:2]))
+		f.engineName = string(data[2:(2 + n)])
+		data = data[(2 + n):]
+	}
+	if len(data) > 5 {
+		n = int(binary.LittleEndian.Uint32(data[0:4]))
+		f.partitionInfo = string(data[4:(4 + n)])
+		data = data[(5 + n):]
+	}
+	if f.mySQLVersionId >= 50110 && len(data) > 0 {
+		data = data[1:]
+	}
+	for i := range f.keys {
+		k := &f.keys[i]
+		if (k.flags & usesParserKeyFlag) == 0 {
+			continue
+		}
+		j := bytes.IndexByte(data, 0)
+		if j < 0 {
+			return
 		}
-		io.WriteString(w, "\n")
+		k.parser = string(data[:j])
+		data = data[(j + 1):]
+	}
+	if form[formCommentPos] == longCommentLength && len(data) > 2 {
+		n = int(binary.LittleEndian.Uint16(data[0:2]))
+		f.comment = string(data[2:(2 + n)])
+	}
+}
+
+// Keys returns the indexes of the table.
+func (f *Frm) Keys() []Key {
+	keys := make([]Key, len(f.keys))
+	for i := range f.keys {
+		keys[i] = f.keys[i].model(f)
+	}
+	return keys
+}
+
+// Columns returns the columns of the table.
+func (f *Frm) Columns() []Column {
+	columns := make([]Column, len(f.columns))
+	for i := range f.columns {
+		columns[i] = f.columns[i].model(f)
+	}
+	return columns
+}
+
+// Comment returns the comment of the table.
+func (f *Frm) Comment() string {
+	return f.comment
+}
+
+// Charset returns the default collation of the table, nil when the file
+// has none.
+func (f *Frm) Charset() *Charset {
+	return f.tableCharset()
+}
+
+// RowFormat returns the ROW_FORMAT option of the table, empty when it is
+// not set.
+func (f *Frm) RowFormat() string {
+	if n := int(f.rowType); n < len(rowFormats) {
+		return rowFormats[n]
+	}
+	return emptyString
+}
+
+func (f *Frm) tableCharsetNum() int {
+	return (int(f.charsetLow) << 8) + int(f.defaultCharset)
+}
+
+func (f *Frm) tableCharset() *Charset {
+	return charsets[f.tableCharsetNum()]
+}
+
+// fixOldColumns reads the columns of files written before MySQL 5.0 the
+// way later servers do. Files of 4.0 have no column character sets: the
+// table character set is used, or its binary collation for BINARY
+// columns, and BLOB columns are binary. The auto-set TIMESTAMP of 4.0
+// defaults to and is updated with the current time.
+func (f *Frm) fixOldColumns() {
+	if !f.isPre50() {
+		return
+	}
+	if f.tableCharsetNum() == 0 {
+		f.defaultCharset = oldDefaultCharset
+	}
+	cs := f.tableCharset()
+	for i := range f.columns {
 		c := &f.columns[i]
-		c.write(w)
+		if c.uniregType == timeStampOldUnireg {
+			c.uniregType = timeStampDnUnUnireg
+		}
+		if cs == nil || !c.hasCharset() || c.charsetNum() != 0 {
+			continue
+		}
+		id := cs.Id
+		if (c.flags & binaryFieldFlag) != 0 {
+			id = binaryCharset
+			if bin, err := CharsetByName(cs.Name + "_bin"); err == nil && !c.isBlob() {
+				id = bin.Id
+			}
+		}
+		c.charset, c.charsetLow = uint8(id), uint8(id>>8)
+	}
+}
+
+func (f *Frm) checkCharsets() error {
+	if n := f.tableCharsetNum(); n != 0 {
+		if _, err := CharsetById(n); err != nil {
+			return err
+		}
 	}
-	l = len(f.keys)
+	l := len(f.columns)
 	for i := 0; i < l; i++ {
-		writeComma(w)
-		io.WriteString(w, "\n")
-		k := &f.keys[i]
-		k.write(w, f.columns)
+		c := &f.columns[i]
+		if !c.hasCharset() {
+			continue
+		}
+		if _, err := CharsetById(c.charsetNum()); err != nil {
+			return err
+		}
 	}
-	writeCloseParen(w)
+	return nil
+}
+
+// WriteCreateTable writes the table definition with its engine and
+// table options, as SHOW CREATE TABLE of the release that wrote the file
+// prints it.
+func (f *Frm) WriteCreateTable(w io.Writer, table string) {
+	f.WriteShowCreateTable(w, table, f.Version())
 }
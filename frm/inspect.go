@@ -0,0 +1,460 @@
+package frm
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+)
+
+const (
+	bytesPerLine = 16
+	screenSize   = 48
+)
+
+// region is a run of bytes of the file the parser reads.
+type region struct {
+	pos     int
+	n       int
+	section string
+	label   string
+	value   string
+}
+
+// inspector lists the regions of a .frm file. Every read is checked
+// against the file size, so that damaged files are shown up to the
+// first region that does not fit.
+type inspector struct {
+	data    []byte
+	f       *Frm
+	regions []region
+	notes   []string
+}
+
+// Inspect writes an annotated hex dump of the .frm file at path: the
+// regions the parser reads with their decoded values, and the bytes it
+// does not read between them. Runs of zero bytes are collapsed, other
+// unread bytes are marked with "!!".
+func Inspect(w io.Writer, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if len(data) < frmStructSize {
+		return WrongFRMFileErr
+	}
+	f := &Frm{}
+	f.read(data)
+	if f.fileType != tableFileType {
+		return WrongFRMFileErr
+	}
+	f.readFormPos(data)
+	in := &inspector{data: data, f: f}
+	in.parse()
+	in.header()
+	in.keys()
+	in.record()
+	in.extra()
+	in.formInfo()
+	in.columns()
+	in.write(w)
+	return nil
+}
+
+// parse runs the parser to decode the default values. A panic on a
+// damaged file is kept as a note.
+func (in *inspector) parse() {
+	defer func() {
+		if r := recover(); r != nil {
+			in.notes = append(in.notes, fmt.Sprint("parser failed: ", r))
+			in.f.columns = nil
+		}
+	}()
+	in.f.readKeys(in.data)
+	in.f.readColumns(in.data)
+	in.f.readExtra(in.data)
+	in.f.fixOldColumns()
+	if err := in.f.readDefaults(in.data); err != nil {
+		in.notes = append(in.notes, fmt.Sprint("defaults: ", err))
+	}
+}
+
+func (in *inspector) add(section string, pos, n int, label, value string) bool {
+	if pos < 0 || n < 0 || pos+n > len(in.data) {
+		in.notes = append(in.notes, fmt.Sprintf("%s: %s at %#x of %d bytes is past the end of the file", section, label, pos, n))
+		return false
+	}
+	in.regions = append(in.regions, region{pos: pos, n: n, section: section, label: label, value: value})
+	return true
+}
+
+func (in *inspector) u8(pos int) int {
+	if pos < 0 || pos >= len(in.data) {
+		return 0
+	}
+	return int(in.data[pos])
+}
+
+func (in *inspector) u16(pos int) int {
+	return in.u8(pos) | in.u8(pos+1)<<8
+}
+
+func (in *inspector) u32(pos int) int {
+	return in.u16(pos) | in.u16(pos+2)<<16
+}
+
+func (in *inspector) text(pos, n int) string {
+	if pos < 0 || n < 0 || pos+n > len(in.data) {
+		return emptyString
+	}
+	return strconv.Quote(string(in.data[pos:(pos + n)]))
+}
+
+func engineLabel(dbType uint8) string {
+	s := strconv.Itoa(int(dbType))
+	if name, ok := legacyEngines[dbType]; ok {
+		s += " " + name
+	}
+	return s
+}
+
+func charsetLabel(id int) string {
+	s := strconv.Itoa(id)
+	if cs, ok := charsets[id]; ok {
+		s += " " + cs.Collate
+	}
+	return s
+}
+
+func (in *inspector) header() {
+	f := in.f
+	const section = "header"
+	fields := []struct {
+		pos, n int
+		label  string
+		value  string
+	}{
+		{0, 2, "file type", fmt.Sprintf("%#04x", f.fileType)},
+		{2, 1, "frm version", strconv.Itoa(int(f.version))},
+		{3, 1, "legacy db type", engineLabel(f.legacyDbType)},
+		{4, 2, "names length", strconv.Itoa(int(f.namesLength))},
+		{6, 2, "io size", strconv.Itoa(int(f.ioSize))},
+		{8, 2, "names", strconv.Itoa(int(f.numNames))},
+		{10, 4, "length", strconv.Itoa(int(f.length))},
+		{14, 2, "tmp key length", strconv.Itoa(int(f.tmpKeyLength))},
+		{16, 2, "record length", strconv.Itoa(int(f.recLength))},
+		{18, 4, "max rows", strconv.Itoa(int(f.maxRows))},
+		{22, 4, "min rows", strconv.Itoa(int(f.minRows))},
+		{26, 2, "db create pack", strconv.Itoa(int(f.dbCreatePack))},
+		{28, 2, "key info length", strconv.Itoa(int(f.keyInfoLength))},
+		{30, 2, "table options", fmt.Sprintf("%#04x", f.tableOptions)},
+		{33, 1, "file version", strconv.Itoa(int(f.fileVersion))},
+		{34, 4, "avg row length", strconv.Itoa(int(f.avgRowLength))},
+		{38, 1, "default charset", charsetLabel(f.tableCharsetNum())},
+		{40, 1, "row type", strconv.Itoa(int(f.rowType))},
+		{41, 1, "charset high byte", strconv.Itoa(int(f.charsetLow))},
+		{42, 2, "stats sample pages", strconv.Itoa(int(f.statSamplePages))},
+		{44, 1, "stats auto recalc", strconv.Itoa(int(f.statAutoRecalc))},
+		{47, 4, "key length", strconv.Itoa(int(f.keyLength))},
+		{51, 4, "mysql version id", strconv.Itoa(int(f.mySQLVersionId))},
+		{55, 4, "extra size", strconv.Itoa(int(f.extraSize))},
+		{59, 2, "extra record buffer length", strconv.Itoa(int(f.extraRecBufLen))},
+		{61, 1, "default partition db type", engineLabel(f.defaultPartDbType)},
+		{62, 2, "key block size", strconv.Itoa(int(f.keyBlockSize))},
+	}
+	for _, fd := range fields {
+		in.add(section, fd.pos, fd.n, fd.label, fd.value)
+	}
+	if f.numNames > 0 {
+		in.add(section, frmStructSize, int(f.namesLength), "form names", in.text(frmStructSize, int(f.namesLength)))
+		in.add(section, frmStructSize+int(f.namesLength), 4, "form position", strconv.Itoa(f.formPos))
+	}
+}
+
+func (in *inspector) keys() {
+	const section = "keys"
+	pos := in.f.keysPos()
+	numKeys := in.u8(pos)
+	numParts := in.u8(pos + 1)
+	if (numKeys & 0x80) > 0 {
+		numKeys = (numParts << 7) | (numKeys & 0x7f)
+		numParts = in.u16(pos + 2)
+	}
+	if !in.add(section, pos, 6, "counts", fmt.Sprintf("%d keys, %d parts", numKeys, numParts)) {
+		return
+	}
+	pos += 6
+	flags := make([]int, numKeys)
+	for i := 0; i < numKeys; i++ {
+		flags[i] = in.u16(pos)
+		n := in.u8(pos + 4)
+		value := fmt.Sprintf("flags %#04x, length %d, %d parts, algorithm %d, block size %d", flags[i], in.u16(pos+2), n, in.u8(pos+5), in.u16(pos+6))
+		if !in.add(section, pos, keyStructSize, "key "+strconv.Itoa(i), value) {
+			return
+		}
+		pos += keyStructSize
+		for j := 0; j < n; j++ {
+			value = fmt.Sprintf("field %d, offset %d, flags %#02x, type %d, length %d", in.u16(pos)&fieldNrMask, in.u16(pos+2), in.u8(pos+4), in.u16(pos+5), in.u16(pos+7))
+			if !in.add(section, pos, partStructSize, "key "+strconv.Itoa(i)+" part "+strconv.Itoa(j), value) {
+				return
+			}
+			pos += partStructSize
+		}
+	}
+	term := in.u8(pos)
+	if !in.add(section, pos, 1, "name separator", fmt.Sprintf("%#02x", term)) {
+		return
+	}
+	pos++
+	for i := 0; i < numKeys; i++ {
+		j := pos
+		for j < len(in.data) && int(in.data[j]) != term {
+			j++
+		}
+		if !in.add(section, pos, j-pos+1, "key "+strconv.Itoa(i)+" name", in.text(pos, j-pos)) {
+			return
+		}
+		pos = j + 1
+	}
+	if in.u8(pos) == 0 {
+		in.add(section, pos, 1, "names end", emptyString)
+		pos++
+	}
+	for i := 0; i < numKeys; i++ {
+		if (flags[i] & usesCommentKeyFlag) == 0 {
+			continue
+		}
+		n := in.u16(pos)
+		if !in.add(section, pos, 2+n, "key "+strconv.Itoa(i)+" comment", in.text(pos+2, n)) {
+			return
+		}
+		pos += 2 + n
+	}
+}
+
+// record lists the default record: the null bits up to the first
+// column, then each column up to the next one.
+func (in *inspector) record() {
+	const section = "record"
+	f := in.f
+	pos := f.recordPos()
+	if len(f.columns) == 0 {
+		in.add(section, pos, int(f.recLength), "default record", emptyString)
+		return
+	}
+	order := make([]int, len(f.columns))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return f.columns[order[i]].recPos < f.columns[order[j]].recPos })
+	first := f.columns[order[0]].recPos - 1
+	if first > 0 {
+		in.add(section, pos, first, "null bits", emptyString)
+	}
+	for n, i := range order {
+		c := &f.columns[i]
+		end := int(f.recLength)
+		if n+1 < len(order) {
+			end = f.columns[order[n+1]].recPos - 1
+		}
+		value := "NULL"
+		if c.hasDefault {
+			value = strconv.Quote(c.defaultValue)
+		}
+		in.add(section, pos+c.recPos-1, end-c.recPos+1, "column "+QuoteIdent(c.name), value)
+	}
+}
+
+func (in *inspector) extra() {
+	const section = "extra"
+	f := in.f
+	pos := f.recordPos() + int(f.recLength)
+	end := pos + int(f.extraSize)
+	if f.extraSize == 0 {
+		return
+	}
+	n := in.u16(pos)
+	if !in.add(section, pos, 2+n, "connect string", in.text(pos+2, n)) {
+		return
+	}
+	pos += 2 + n
+	if end-pos > 2 {
+		n = in.u16(pos)
+		if !in.add(section, pos, 2+n, "engine name", in.text(pos+2, n)) {
+			return
+		}
+		pos += 2 + n
+	}
+	if end-pos > 5 {
+		n = in.u32(pos)
+		if !in.add(section, pos, 5+n, "partition info", in.text(pos+4, n)) {
+			return
+		}
+		pos += 5 + n
+	}
+	if f.mySQLVersionId >= 50110 && end-pos > 0 {
+		in.add(section, pos, 1, "autopartition flag", strconv.Itoa(in.u8(pos)))
+		pos++
+	}
+	for i := range f.keys {
+		if (f.keys[i].flags & usesParserKeyFlag) == 0 {
+			continue
+		}
+		j := pos
+		for j < end && j < len(in.data) && in.data[j] != 0 {
+			j++
+		}
+		if !in.add(section, pos, j-pos+1, "key "+strconv.Itoa(i)+" parser", in.text(pos, j-pos)) {
+			return
+		}
+		pos = j + 1
+	}
+	if in.u8(f.columnsPos()-formInfoSize+formCommentPos) == longCommentLength && end-pos > 2 {
+		n = in.u16(pos)
+		in.add(section, pos, 2+n, "table comment", in.text(pos+2, n))
+	}
+}
+
+func (in *inspector) formInfo() {
+	const section = "forminfo"
+	pos := in.f.columnsPos() - formInfoSize + formCommentPos
+	n := in.u8(pos)
+	if n == longCommentLength {
+		in.add(section, pos, 1, "comment length", "in the extra segment")
+		return
+	}
+	in.add(section, pos, 1+n, "table comment", in.text(pos+1, n))
+}
+
+func (in *inspector) columns() {
+	const section = "columns"
+	pos := in.f.columnsPos()
+	numScreens := in.u16(pos)
+	numColumns := in.u16(pos + 2)
+	namesLength := in.u16(pos + 12)
+	numIntervals := in.u16(pos + 14)
+	intervalsLength := in.u16(pos + 18)
+	fields := []struct {
+		pos, n int
+		label  string
+		value  int
+	}{
+		{0, 2, "screens", numScreens},
+		{2, 2, "columns", numColumns},
+		{12, 2, "names length", namesLength},
+		{14, 2, "intervals", numIntervals},
+		{18, 2, "intervals length", intervalsLength},
+	}
+	for _, fd := range fields {
+		if !in.add(section, pos+fd.pos, fd.n, fd.label, strconv.Itoa(fd.value)) {
+			return
+		}
+	}
+	pos += 32
+	for i := 0; i < numScreens; i++ {
+		numNames := in.u8(pos + 3)
+		if !in.add(section, pos+3, 1, "screen "+strconv.Itoa(i)+" names", strconv.Itoa(numNames)) {
+			return
+		}
+		pos += screenSize
+		for j := 0; j < numNames; j++ {
+			n := in.u8(pos + 2)
+			if !in.add(section, pos+2, 1+n, "screen name", in.text(pos+3, n-1)) {
+				return
+			}
+			pos += 3 + n
+		}
+	}
+	comments := 0
+	for i := 0; i < numColumns; i++ {
+		d := pos + 3
+		value := fmt.Sprintf("length %d, record %d, flags %#04x, unireg %d, type %d, charset %s, interval %d, comment %d",
+			in.u16(d), in.u16(d+2)|in.u8(d+4)<<16, in.u16(d+5), in.u8(d+7), in.u8(d+10), charsetLabel(in.u8(d+8)<<8|in.u8(d+11)), in.u8(d+9), in.u16(d+12))
+		if !in.add(section, d, columnStructSize-3, "column "+strconv.Itoa(i), value) {
+			return
+		}
+		comments += in.u16(d + 12)
+		pos += columnStructSize
+	}
+	if !in.add(section, pos, namesLength, "names", in.text(pos, namesLength)) {
+		return
+	}
+	pos += namesLength
+	if !in.add(section, pos, intervalsLength, "intervals", in.text(pos, intervalsLength)) {
+		return
+	}
+	pos += intervalsLength
+	in.add(section, pos, comments, "column comments", in.text(pos, comments))
+}
+
+func writeHex(w io.Writer, pos int, b []byte, label string) {
+	for i := 0; i < len(b) || i == 0; i += bytesPerLine {
+		end := i + bytesPerLine
+		if end > len(b) {
+			end = len(b)
+		}
+		line := fmt.Sprintf("%06x  % x", pos+i, b[i:end])
+		if i == 0 {
+			fmt.Fprintf(w, "%-56s  %s\n", line, label)
+		} else {
+			fmt.Fprintln(w, line)
+		}
+	}
+}
+
+// writeGap writes the bytes between regions. Runs of zero bytes of a
+// line or more are collapsed, the other bytes are marked.
+func (in *inspector) writeGap(w io.Writer, pos, end int) {
+	for pos < end {
+		z := pos
+		for z < end && in.data[z] == 0 {
+			z++
+		}
+		if z-pos >= bytesPerLine || z == end {
+			fmt.Fprintf(w, "%06x  .. %06x %40s  %d zero bytes\n", pos, z-1, emptyString, z-pos)
+			pos = z
+			continue
+		}
+		e := z
+		for e < end {
+			if in.data[e] != 0 {
+				e++
+				continue
+			}
+			r := e
+			for r < end && in.data[r] == 0 {
+				r++
+			}
+			if r-e >= bytesPerLine || r == end {
+				break
+			}
+			e = r
+		}
+		writeHex(w, pos, in.data[pos:e], fmt.Sprintf("!! %d unread bytes", e-pos))
+		pos = e
+	}
+}
+
+func (in *inspector) write(w io.Writer) {
+	sort.SliceStable(in.regions, func(i, j int) bool { return in.regions[i].pos < in.regions[j].pos })
+	pos := 0
+	for _, r := range in.regions {
+		in.writeGap(w, pos, r.pos)
+		label := r.section + ": " + r.label
+		if r.value != emptyString {
+			label += " = " + r.value
+		}
+		if r.pos < pos {
+			label = "!! overlaps: " + label
+		}
+		writeHex(w, r.pos, in.data[r.pos:(r.pos+r.n)], label)
+		if end := r.pos + r.n; end > pos {
+			pos = end
+		}
+	}
+	in.writeGap(w, pos, len(in.data))
+	for _, note := range in.notes {
+		fmt.Fprintln(w, "!! "+note)
+	}
+}
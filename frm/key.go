@@ -2,7 +2,6 @@ package frm
 
 import (
 	"encoding/binary"
-	"io"
 )
 
 type key struct {
@@ -12,9 +11,43 @@ type key struct {
 	algorithm uint8
 	blockSize uint16
 	name      string
+	comment   string
+	parser    string
 	parts     []part
 }
 
+// KeyType is the kind of an index as written by SHOW CREATE TABLE.
+type KeyType string
+
+const (
+	PrimaryKey  KeyType = "PRIMARY KEY"
+	UniqueKey   KeyType = "UNIQUE KEY"
+	FullTextKey KeyType = "FULLTEXT KEY"
+	SpatialKey  KeyType = "SPATIAL KEY"
+	IndexKey    KeyType = "KEY"
+)
+
+// Key describes an index of the table.
+type Key struct {
+	Name      string
+	Type      KeyType
+	Algorithm string
+	BlockSize int
+	Comment   string
+	Parser    string
+	Parts     []KeyPart
+}
+
+// KeyPart is a column of an index. Length is the prefix length in
+// characters and zero when the whole column is indexed. A functional key
+// part has the expression it indexes in Expr and no Column.
+type KeyPart struct {
+	Column string
+	Length int
+	Desc   bool
+	Expr   string
+}
+
 func (k *key) read(d []byte) {
 	k.flags = binary.LittleEndian.Uint16(d[0:2])
 	k.length = binary.LittleEndian.Uint16(d[2:4])
@@ -23,53 +56,85 @@ func (k *key) read(d []byte) {
 	k.blockSize = binary.LittleEndian.Uint16(d[6:8])
 }
 
-func (k *key) write(w io.Writer, columns []column) {
-	if k.name == "PRIMARY" {
-		writeString(w, "PRIMARY KEY")
-	} else {
-		if (k.flags & allowDupsKeyFlag) == 0 {
-			writeString(w, "UNIQUE KEY")
-		} else {
-			writeString(w, "KEY")
-		}
-		writeSpace(w)
-		writeQuoted(w, k.name)
+func (k *key) keyType() KeyType {
+	switch {
+	case k.name == primaryKeyName:
+		return PrimaryKey
+	case (k.flags & allowDupsKeyFlag) == 0:
+		return UniqueKey
+	case (k.flags & fullTextKeyFlag) != 0:
+		return FullTextKey
+	case (k.flags & spatialKeyFlag) != 0:
+		return SpatialKey
 	}
-	writeOpenParen(w)
-	l := len(k.parts)
-	for i := 0; i < l; i++ {
-		p := &k.parts[i]
-		c := &columns[p.fieldNumA()]
-		if i > 0 {
-			writeComma(w)
-		}
-		writeQuoted(w, c.name)
-		z := int(p.length)
-		switch c.fieldType {
-		case varCharFieldType,
-			stringFieldType,
-			tinyBlobFieldType,
-			mediumBlobFieldType,
-			longBlobFieldType,
-			blobFieldType:
-			cn := c.charsetNum()
-			if cn != binaryCharset {
-				z = z / c.charsetA().maxLen
-			}
-			writeParened(w, z)
-		case geometryFieldType:
-			writeParened(w, z)
-		}
-	}
-	writeCloseParen(w)
+	return IndexKey
+}
+
+// algorithmName returns the USING clause value SHOW CREATE TABLE writes:
+// the implied RTREE of spatial and the FULLTEXT algorithm are omitted.
+func (k *key) algorithmName() string {
 	switch k.algorithm {
 	case bTreeKeyAlgo:
-		writeString(w, " USING BTREE")
-	case rTreeKeyAlgo:
-		writeString(w, " USING RTREE")
+		return "BTREE"
 	case hashKeyAlgo:
-		writeString(w, " USING HASH")
-	case fullTextKeyAlgo:
-		writeString(w, " USING FULLTEXT")
+		return "HASH"
+	case rTreeKeyAlgo:
+		if (k.flags & spatialKeyFlag) == 0 {
+			return "RTREE"
+		}
+	}
+	return emptyString
+}
+
+// prefixLength returns the prefix length in characters of the part, or
+// zero when the part covers the whole column.
+func (k *key) prefixLength(p *part, c *column) int {
+	if (k.flags & (fullTextKeyFlag | spatialKeyFlag)) != 0 {
+		return 0
+	}
+	z := int(p.length)
+	switch c.fieldType {
+	case varCharFieldType,
+		varStringFieldType,
+		stringFieldType:
+		if z == int(c.fieldLength) {
+			return 0
+		}
+	case tinyBlobFieldType,
+		mediumBlobFieldType,
+		longBlobFieldType,
+		blobFieldType,
+		geometryFieldType,
+		jsonFieldType:
+	default:
+		return 0
+	}
+	if c.fieldType != geometryFieldType && c.charsetNum() != binaryCharset {
+		z = z / c.maxLen()
+	}
+	return z
+}
+
+func (k *key) model(f *Frm) Key {
+	m := Key{
+		Name:      k.name,
+		Type:      k.keyType(),
+		Algorithm: k.algorithmName(),
+		Comment:   k.comment,
+		Parser:    k.parser,
+		Parts:     make([]KeyPart, len(k.parts)),
+	}
+	if (k.flags&usesBlockSizeKeyFlag) != 0 && k.blockSize != f.keyBlockSize {
+		m.BlockSize = int(k.blockSize)
+	}
+	for i := range k.parts {
+		p := &k.parts[i]
+		if p.expr != emptyString {
+			m.Parts[i] = KeyPart{Expr: p.expr, Desc: p.isDesc()}
+			continue
+		}
+		c := &f.columns[p.fieldNumA()]
+		m.Parts[i] = KeyPart{Column: c.name, Length: k.prefixLength(p, c), Desc: p.isDesc()}
 	}
+	return m
 }
@@ -0,0 +1,279 @@
+package frm
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	unreadableRule = "unreadable-table"
+)
+
+var (
+	// words of column names that hold amounts of money
+	moneyWords = []string{
+		"amount",
+		"balance",
+		"cost",
+		"fee",
+		"money",
+		"payment",
+		"price",
+		"salary",
+		"tax",
+		"total",
+	}
+	// types checkLongKeyParts checks, as SHOW CREATE TABLE prints them
+	charTypePrefixes = []string{"varchar(", "char(", "varbinary(", "binary("}
+	// DefaultRules are the rules Lint applies when none are given.
+	DefaultRules = []Rule{
+		{Name: "no-primary-key", Check: checkPrimaryKey},
+		{Name: "redundant-key", Check: checkRedundantKeys},
+		{Name: "nullable-unique", Check: checkNullableUnique},
+		{Name: "long-key-part", Check: checkLongKeyParts},
+		{Name: "mixed-charsets", Check: checkMixedCharsets},
+		{Name: "float-money", Check: checkFloatMoney},
+	}
+)
+
+// Rule is a named check of a table definition. Check returns the
+// problems found, custom rules may use the exported view of the table.
+type Rule struct {
+	Name  string
+	Check func(f *Frm) []Problem
+}
+
+// Finding is a problem a rule found in a table.
+type Finding struct {
+	Rule    string `json:"rule"`
+	Table   string `json:"table"`
+	Object  string `json:"object"`
+	Message string `json:"message"`
+}
+
+func (fd Finding) String() string {
+	return QuoteIdent(fd.Table) + " " + fd.Object + ": " + fd.Message + " [" + fd.Rule + "]"
+}
+
+// Lint applies the rules to the table and returns the findings. The
+// default rules are applied when rules is nil.
+func (f *Frm) Lint(table string, rules []Rule) []Finding {
+	if rules == nil {
+		rules = DefaultRules
+	}
+	findings := make([]Finding, 0)
+	for _, r := range rules {
+		for _, p := range r.Check(f) {
+			findings = append(findings, Finding{Rule: r.Name, Table: table, Object: p.Object, Message: p.Message})
+		}
+	}
+	return findings
+}
+
+// LintDir applies the rules to every table of the database directory
+// dir. A table that cannot be read is a finding of its own.
+func LintDir(dir string, rules []Rule) ([]Finding, error) {
+	findings := make([]Finding, 0)
+	err := readDir(dir, func(table string, f *Frm) {
+		findings = append(findings, f.Lint(table, rules)...)
+	}, func(table string, err error) {
+		findings = append(findings, Finding{Rule: unreadableRule, Table: table, Object: tableObject,
+			Message: "not checked, the .frm file cannot be read: " + err.Error()})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// WriteFindings writes the findings one per line.
+func WriteFindings(w io.Writer, findings []Finding) {
+	for _, fd := range findings {
+		writeString(w, fd.String())
+		writeString(w, "\n")
+	}
+}
+
+// WriteFindingsJSON writes the findings as a JSON array.
+func WriteFindingsJSON(w io.Writer, findings []Finding) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent(emptyString, "  ")
+	return enc.Encode(findings)
+}
+
+func checkPrimaryKey(f *Frm) []Problem {
+	for _, k := range f.Keys() {
+		if k.Type == PrimaryKey {
+			return nil
+		}
+	}
+	return []Problem{{Object: tableObject, Message: "has no primary key, row based replication scans the table for each row"}}
+}
+
+// isLeftPrefix reports whether the parts of a start the parts of b.
+func isLeftPrefix(a, b *Key) bool {
+	if len(a.Parts) > len(b.Parts) {
+		return false
+	}
+	for i := range a.Parts {
+		if a.Parts[i] != b.Parts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isBTreeKey(k *Key) bool {
+	return k.Type != FullTextKey && k.Type != SpatialKey
+}
+
+func checkRedundantKeys(f *Frm) []Problem {
+	keys := f.Keys()
+	problems := make([]Problem, 0)
+	for i := range keys {
+		a := &keys[i]
+		if !isBTreeKey(a) || a.Type == PrimaryKey {
+			continue
+		}
+		// a duplicate is reported rather than a longer key
+		found, same := -1, false
+		for j := range keys {
+			b := &keys[j]
+			if i == j || !isBTreeKey(b) || !isLeftPrefix(a, b) {
+				continue
+			}
+			equal := len(a.Parts) == len(b.Parts)
+			switch {
+			case a.Type == UniqueKey && (!equal || b.Type == IndexKey):
+				continue
+			case equal && a.Type == b.Type && j > i:
+				// the later of two equal keys is reported
+				continue
+			}
+			if found < 0 || equal {
+				found, same = j, equal
+			}
+			if equal {
+				break
+			}
+		}
+		switch {
+		case found < 0:
+		case same:
+			problems = append(problems, Problem{Object: keyObject(a.Name), Message: "duplicates " + keyObject(keys[found].Name)})
+		default:
+			problems = append(problems, Problem{Object: keyObject(a.Name), Message: "is redundant with " + keyObject(keys[found].Name)})
+		}
+	}
+	return problems
+}
+
+// columnsByName returns the columns of a table by name, for the parts of
+// its keys.
+func columnsByName(f *Frm) map[string]*Column {
+	columns := f.Columns()
+	byName := make(map[string]*Column, len(columns))
+	for i := range columns {
+		byName[columns[i].Name] = &columns[i]
+	}
+	return byName
+}
+
+func checkNullableUnique(f *Frm) []Problem {
+	columns := columnsByName(f)
+	problems := make([]Problem, 0)
+	for _, k := range f.Keys() {
+		if k.Type != UniqueKey {
+			continue
+		}
+		names := make([]string, 0)
+		for _, p := range k.Parts {
+			if p.Expr == emptyString && columns[p.Column].Nullable {
+				names = append(names, p.Column)
+			}
+		}
+		if len(names) > 0 {
+			problems = append(problems, Problem{Object: keyObject(k.Name), Message: "allows several rows with NULL in " + QuoteIdents(names)})
+		}
+	}
+	return problems
+}
+
+// checkLongKeyParts finds VARCHAR and CHAR columns indexed with more
+// bytes than the row format of the table allows on the release that
+// wrote it.
+func checkLongKeyParts(f *Frm) []Problem {
+	limit := maxPrefixLength(f.rowType, f.Version())
+	columns := columnsByName(f)
+	problems := make([]Problem, 0)
+	for _, k := range f.Keys() {
+		if !isBTreeKey(&k) {
+			continue
+		}
+		for _, p := range k.Parts {
+			c := columns[p.Column]
+			if p.Expr != emptyString || !isCharType(c.Type) {
+				continue
+			}
+			length := c.Length
+			if p.Length > 0 {
+				length = p.Length
+				if c.Charset != nil {
+					length *= c.Charset.MaxLen
+				}
+			}
+			if length > limit {
+				problems = append(problems, Problem{Object: keyObject(k.Name), Message: "indexes " + QuoteIdent(c.Name) + " with " + strconv.Itoa(length) + " bytes, use a shorter column or a prefix"})
+			}
+		}
+	}
+	return problems
+}
+
+// isCharType reports whether a column type is one of the fixed or
+// variable length strings.
+func isCharType(typ string) bool {
+	for _, prefix := range charTypePrefixes {
+		if strings.HasPrefix(typ, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkMixedCharsets(f *Frm) []Problem {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, c := range f.Columns() {
+		if c.Charset == nil || c.Charset.IsBinary() || seen[c.Charset.Name] {
+			continue
+		}
+		seen[c.Charset.Name] = true
+		names = append(names, c.Charset.Name)
+	}
+	if len(names) < 2 {
+		return nil
+	}
+	sort.Strings(names)
+	return []Problem{{Object: tableObject, Message: "mixes character sets " + strings.Join(names, ", ")}}
+}
+
+func checkFloatMoney(f *Frm) []Problem {
+	problems := make([]Problem, 0)
+	for _, c := range f.Columns() {
+		if !strings.HasPrefix(c.Type, "float") && !strings.HasPrefix(c.Type, "double") {
+			continue
+		}
+		name := strings.ToLower(c.Name)
+		for _, word := range moneyWords {
+			if strings.Contains(name, word) {
+				problems = append(problems, Problem{Object: columnObject(c.Name), Message: "stores money in an approximate type, use DECIMAL"})
+				break
+			}
+		}
+	}
+	return problems
+}
@@ -3,16 +3,47 @@ package frm
 import (
 	"io"
 	"strconv"
+	"strings"
 )
 
+var (
+	identReplacer  = strings.NewReplacer("`", "``")
+	stringReplacer = strings.NewReplacer(
+		"\\", "\\\\",
+		"'", "\\'",
+		"\x00", "\\0",
+		"\n", "\\n",
+		"\r", "\\r",
+		"\x1a", "\\Z",
+	)
+)
+
+// QuoteIdent returns s as a backtick quoted SQL identifier.
+func QuoteIdent(s string) string {
+	return "`" + identReplacer.Replace(s) + "`"
+}
+
+// QuoteIdents returns the names quoted by QuoteIdent and separated by
+// commas.
+func QuoteIdents(names []string) string {
+	quoted := make([]string, len(names))
+	for i, s := range names {
+		quoted[i] = QuoteIdent(s)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// QuoteString returns s as a single quoted SQL string literal.
+func QuoteString(s string) string {
+	return "'" + stringReplacer.Replace(s) + "'"
+}
+
 func writeString(w io.Writer, s string) {
 	io.WriteString(w, s)
 }
 
 func writeQuoted(w io.Writer, s string) {
-	writeString(w, "`")
-	writeString(w, s)
-	writeString(w, "`")
+	writeString(w, QuoteIdent(s))
 }
 
 func writeOpenParen(w io.Writer) {
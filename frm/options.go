@@ -0,0 +1,168 @@
+package frm
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	mergeEngine        = "MRG_MYISAM"
+	mrgExt             = "MRG"
+	mydExt             = "MYD"
+	myiExt             = "MYI"
+	islExt             = "isl"
+	insertMethodPrefix = "#INSERT_METHOD="
+)
+
+// MergeTable is a table of the UNION of a MERGE table. Database is empty
+// for the tables of the database of the MERGE table.
+type MergeTable struct {
+	Database string
+	Table    string
+}
+
+// EngineOptions are the table options of the storage engine. Connection
+// is kept in the .frm file, the others in the files of the engine: the
+// .MRG file of a MERGE table, the symbolic links of the MyISAM data and
+// index files and the .isl file of an InnoDB table created with DATA
+// DIRECTORY. Directories end with a slash.
+type EngineOptions struct {
+	Connection     string
+	Union          []MergeTable
+	InsertMethod   string
+	DataDirectory  string
+	IndexDirectory string
+}
+
+// EngineOptions returns the storage engine options of the table.
+func (f *Frm) EngineOptions() EngineOptions {
+	return EngineOptions{
+		Connection:     f.connectString,
+		Union:          f.union,
+		InsertMethod:   f.insertMethod,
+		DataDirectory:  f.dataDirectory,
+		IndexDirectory: f.indexDirectory,
+	}
+}
+
+// readEngineFiles reads the options the engine keeps next to the .frm
+// file at path. Missing files leave the options unset.
+func (f *Frm) readEngineFiles(path string) error {
+	base := strings.TrimSuffix(path, "."+frmExt)
+	var err error
+	switch f.Engine() {
+	case mergeEngine:
+		err = f.readMrg(base + "." + mrgExt)
+	case myISAMEngine:
+		if f.dataDirectory, err = readLinkDir(base + "." + mydExt); err == nil {
+			f.indexDirectory, err = readLinkDir(base + "." + myiExt)
+		}
+	case innoDBEngine:
+		f.dataDirectory, err = readIsl(base + "." + islExt)
+	}
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// readMrg reads the .MRG file listing the tables of a MERGE table, one
+// per line. Tables of the same database are listed by their file name,
+// others by their path.
+func (f *Frm) readMrg(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Base(filepath.Dir(path))
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case line == emptyString:
+		case strings.HasPrefix(line, insertMethodPrefix):
+			f.insertMethod = strings.TrimPrefix(line, insertMethodPrefix)
+		case strings.HasPrefix(line, "#"):
+		default:
+			t := MergeTable{Table: decodeName(filepath.Base(line))}
+			if db := filepath.Base(filepath.Dir(line)); db != "." && db != dir {
+				t.Database = decodeName(db)
+			}
+			f.union = append(f.union, t)
+		}
+	}
+	return nil
+}
+
+// decodeName decodes a file name, keeping names that are not encoded.
+func decodeName(s string) string {
+	if name, err := DecodeFilename(s); err == nil {
+		return name
+	}
+	return s
+}
+
+// readLinkDir returns the directory of the file a symbolic link points
+// to, or nothing when path is a plain file.
+func readLinkDir(path string) (string, error) {
+	fi, err := os.Lstat(path)
+	if err != nil || (fi.Mode()&os.ModeSymlink) == 0 {
+		return emptyString, err
+	}
+	target, err := os.Readlink(path)
+	if err != nil {
+		return emptyString, err
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+	return filepath.Dir(target) + "/", nil
+}
+
+// readIsl returns the DATA DIRECTORY of an InnoDB table from its .isl
+// file, which holds the path of the .ibd file in a directory named after
+// the database.
+func readIsl(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return emptyString, err
+	}
+	ibd := strings.TrimSpace(string(data))
+	return filepath.Dir(filepath.Dir(ibd)) + "/", nil
+}
+
+func (f *Frm) writeShowEngineOptions(w io.Writer) {
+	if f.insertMethod != emptyString {
+		writeString(w, " INSERT_METHOD=")
+		writeString(w, f.insertMethod)
+	}
+	if len(f.union) == 0 {
+		return
+	}
+	writeString(w, " UNION=")
+	writeOpenParen(w)
+	for i, t := range f.union {
+		if i > 0 {
+			writeComma(w)
+		}
+		if t.Database != emptyString {
+			writeQuoted(w, t.Database)
+			writeString(w, ".")
+		}
+		writeQuoted(w, t.Table)
+	}
+	writeCloseParen(w)
+}
+
+func (f *Frm) writeShowDirectories(w io.Writer, v Version) {
+	if f.dataDirectory != emptyString {
+		writeString(w, " DATA DIRECTORY=")
+		writeString(w, quoteShow(f.dataDirectory, v))
+	}
+	if f.indexDirectory != emptyString {
+		writeString(w, " INDEX DIRECTORY=")
+		writeString(w, quoteShow(f.indexDirectory, v))
+	}
+}
@@ -0,0 +1,111 @@
+package frm
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	WrongParseFileErr = errors.New("Wrong view or trigger file.")
+)
+
+const (
+	parseFileTypeKey  = "TYPE"
+	viewParseType     = "VIEW"
+	triggersParseType = "TRIGGERS"
+	dbOptFile         = "db.opt"
+	dbOptCharset      = "default-character-set"
+	dbOptCollation    = "default-collation"
+)
+
+var (
+	parseFileUnescaper = strings.NewReplacer(
+		"\\\\", "\\",
+		"\\n", "\n",
+		"\\0", "\x00",
+		"\\z", "\x1a",
+		"\\'", "'",
+	)
+)
+
+// readOptions reads the name=value lines of a file. Values of the same
+// name replace earlier ones.
+func readOptions(path string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	options := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.IndexByte(line, '='); i > 0 {
+			options[line[:i]] = strings.TrimRight(line[(i+1):], "\r")
+		}
+	}
+	return options, nil
+}
+
+// readParseFile reads the .frm file of a view or the .TRG file of the
+// triggers of a table: a TYPE line naming the kind of file followed by
+// name=value lines, with values escaped.
+func readParseFile(path, fileType string) (map[string]string, error) {
+	options, err := readOptions(path)
+	if err != nil {
+		return nil, err
+	}
+	if options[parseFileTypeKey] != fileType {
+		return nil, WrongParseFileErr
+	}
+	return options, nil
+}
+
+func unescapeValue(s string) string {
+	return parseFileUnescaper.Replace(s)
+}
+
+// splitValues splits a list value: strings in single quotes or numbers,
+// separated by spaces.
+func splitValues(s string) []string {
+	values := make([]string, 0)
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == ' ':
+			i++
+		case s[i] == '\'':
+			j := i + 1
+			for j < len(s) && s[j] != '\'' {
+				if s[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j > len(s) {
+				j = len(s)
+			}
+			values = append(values, unescapeValue(s[(i+1):j]))
+			i = j + 1
+		default:
+			j := strings.IndexByte(s[i:], ' ')
+			if j < 0 {
+				j = len(s) - i
+			}
+			values = append(values, s[i:(i+j)])
+			i += j
+		}
+	}
+	return values
+}
+
+// ReadDbOpt returns the default collation of a database from the db.opt
+// file of its directory.
+func ReadDbOpt(dir string) (*Charset, error) {
+	options, err := readOptions(filepath.Join(dir, dbOptFile))
+	if err != nil {
+		return nil, err
+	}
+	if collate, ok := options[dbOptCollation]; ok {
+		return CharsetByName(collate)
+	}
+	return DefaultCharset(options[dbOptCharset], 0)
+}
@@ -4,22 +4,29 @@ import (
 	"encoding/binary"
 )
 
+// part is a key part. The part of a functional key has the expression
+// of its hidden column in expr and no field number.
 type part struct {
 	fieldNum    uint16
 	offset      uint16
 	keyType     uint16
 	keyPartFlag uint8
 	length      uint16
+	expr        string
 }
 
 func (p *part) read(d []byte) {
 	p.fieldNum = binary.LittleEndian.Uint16(d[0:2])
 	p.offset = binary.LittleEndian.Uint16(d[2:4])
-	p.keyType = binary.LittleEndian.Uint16(d[4:6])
-	p.keyPartFlag = d[6]
+	p.keyPartFlag = d[4]
+	p.keyType = binary.LittleEndian.Uint16(d[5:7])
 	p.length = binary.LittleEndian.Uint16(d[7:9])
 }
 
 func (p *part) fieldNumA() int {
-	return (int(p.fieldNum) & 0x3FFF) - 1
+	return (int(p.fieldNum) & fieldNrMask) - 1
+}
+
+func (p *part) isDesc() bool {
+	return (p.keyPartFlag & reverseSortPartFlag) != 0
 }
@@ -0,0 +1,369 @@
+package frm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	timeIntOffset     = 0x800000
+	time6IntOffset    = 0x800000000000
+	dateTimeIntOffset = 0x8000000000
+	timeWidth         = 10
+	dateTimeWidth     = 19
+	zeroDate          = "0000-00-00"
+	zeroTime          = "00:00:00"
+	digitsPerDecimal  = 9
+)
+
+// readDefaults decodes the default values of the columns from the
+// default record into the text SHOW CREATE TABLE prints. A column whose
+// default cannot be decoded has none and the error is returned.
+func (f *Frm) readDefaults(data []byte) error {
+	pos := f.recordPos()
+	end := pos + int(f.recLength)
+	if end > len(data) {
+		return nil
+	}
+	rec := data[pos:end]
+	nullBit := 1
+	if (f.tableOptions & packRecordOption) != 0 {
+		nullBit = 0
+	}
+	var err error
+	for i := range f.columns {
+		c := &f.columns[i]
+		isNull := false
+		if c.isNullable() {
+			isNull = (rec[nullBit/8] & (1 << uint(nullBit%8))) != 0
+			nullBit++
+		}
+		bitPos := nullBit
+		if c.fieldType == bitFieldType {
+			nullBit += int(c.fieldLength) & 7
+		}
+		if isNull || c.recPos < 1 || c.recPos > len(rec) {
+			continue
+		}
+		value, ok, verr := c.value(rec[(c.recPos-1):], rec, bitPos)
+		if verr != nil {
+			if err == nil {
+				err = verr
+			}
+			continue
+		}
+		c.defaultValue, c.hasDefault = value, ok
+	}
+	return err
+}
+
+func readInt(d []byte, n int, signed bool) int64 {
+	var u uint64
+	for i := n - 1; i >= 0; i-- {
+		u = u<<8 | uint64(d[i])
+	}
+	if signed && n < 8 && (u&(1<<uint(n*8-1))) != 0 {
+		u |= math.MaxUint64 << uint(n*8)
+	}
+	return int64(u)
+}
+
+func readBigEndian(d []byte, n int) uint64 {
+	var u uint64
+	for i := 0; i < n; i++ {
+		u = u<<8 | uint64(d[i])
+	}
+	return u
+}
+
+// value decodes the column value at d. Blobs, geometries and JSON have no
+// default value and false is returned for them.
+func (c *column) value(d, rec []byte, bitPos int) (string, bool, error) {
+	switch c.fieldType {
+	case tinyFieldType:
+		return c.intValue(d, 1), true, nil
+	case shortFieldType:
+		return c.intValue(d, 2), true, nil
+	case int24FieldType:
+		return c.intValue(d, 3), true, nil
+	case longFieldType:
+		return c.intValue(d, 4), true, nil
+	case longLongFieldType:
+		return c.intValue(d, 8), true, nil
+	case yearFieldType:
+		if d[0] == 0 {
+			return "0000", true, nil
+		}
+		return strconv.Itoa(1900 + int(d[0])), true, nil
+	case floatFieldType:
+		v := math.Float32frombits(binary.LittleEndian.Uint32(d))
+		return c.zeroFill(c.floatValue(float64(v), 32)), true, nil
+	case doubleFieldType:
+		v := math.Float64frombits(binary.LittleEndian.Uint64(d))
+		return c.zeroFill(c.floatValue(v, 64)), true, nil
+	case newDecimalFieldType:
+		return c.zeroFill(readDecimal(d, c.precision(), c.decimals())), true, nil
+	case decimalFieldType:
+		return strings.TrimLeft(string(d[:c.fieldLength]), " "), true, nil
+	case bitFieldType:
+		return c.bitValue(d, rec, bitPos), true, nil
+	case newDateFieldType:
+		v := int(readInt(d, 3, false))
+		return fmt.Sprintf("%04d-%02d-%02d", v>>9, (v>>5)&15, v&31), true, nil
+	case dateFieldType:
+		v := int(readInt(d, 4, false))
+		return fmt.Sprintf("%04d-%02d-%02d", v/10000, v/100%100, v%100), true, nil
+	case timeFieldType:
+		v := readInt(d, 3, true)
+		sign := emptyString
+		if v < 0 {
+			sign, v = "-", -v
+		}
+		return fmt.Sprintf("%s%02d:%02d:%02d", sign, v/10000, v/100%100, v%100), true, nil
+	case dateTimeFieldType:
+		v := readInt(d, 8, false)
+		return fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d", v/10000000000, v/100000000%100,
+			v/1000000%100, v/10000%100, v/100%100, v%100), true, nil
+	case timeStampFieldType:
+		return timeStampValue(readInt(d, 4, false), 0, 0), true, nil
+	case time2FieldType:
+		return c.time2Value(d), true, nil
+	case dateTime2FieldType:
+		return c.dateTime2Value(d), true, nil
+	case timeStamp2FieldType:
+		fsp := c.fractionDigits(dateTimeWidth)
+		return timeStampValue(int64(readBigEndian(d, 4)), fraction(d[4:], fsp), fsp), true, nil
+	case varStringFieldType:
+		// VARCHAR before MySQL 5.0.3 is padded like CHAR
+		s, err := c.charValue(d[:c.fieldLength])
+		return s, true, err
+	case varCharFieldType:
+		n, l := 1, int(d[0])
+		if c.fieldLength > 255 {
+			n, l = 2, int(binary.LittleEndian.Uint16(d))
+		}
+		s, err := c.stringValue(d[n:(n + l)])
+		return s, true, err
+	case stringFieldType:
+		s, err := c.charValue(d[:c.fieldLength])
+		return s, true, err
+	case enumFieldType:
+		n := 1
+		if len(c.intervals) > 255 {
+			n = 2
+		}
+		i := int(readInt(d, n, false))
+		if i == 0 || i > len(c.intervals) {
+			return emptyString, true, nil
+		}
+		s, err := c.stringValue([]byte(c.intervals[i-1]))
+		return s, true, err
+	case setFieldType:
+		n := (len(c.intervals) + 7) / 8
+		if n > 4 {
+			n = 8
+		}
+		bits := uint64(readInt(d, n, false))
+		values := make([]string, 0)
+		for i, s := range c.intervals {
+			if (bits & (1 << uint(i))) != 0 {
+				v, err := c.stringValue([]byte(s))
+				if err != nil {
+					return emptyString, false, err
+				}
+				values = append(values, v)
+			}
+		}
+		return strings.Join(values, ","), true, nil
+	}
+	return emptyString, false, nil
+}
+
+func (c *column) intValue(d []byte, n int) string {
+	v := readInt(d, n, !c.isUnsigned())
+	if c.isUnsigned() {
+		return c.zeroFill(strconv.FormatUint(uint64(v), 10))
+	}
+	return c.zeroFill(strconv.FormatInt(v, 10))
+}
+
+func (c *column) zeroFill(s string) string {
+	if c.isZeroFill() && len(s) < int(c.fieldLength) {
+		return strings.Repeat("0", int(c.fieldLength)-len(s)) + s
+	}
+	return s
+}
+
+// floatValue formats like the server: fixed decimals when the column has
+// them, otherwise the shortest form with a plain exponent.
+func (c *column) floatValue(v float64, bits int) string {
+	if dec := c.decimals(); dec != notFixedDec {
+		return strconv.FormatFloat(v, 'f', dec, bits)
+	}
+	s := strconv.FormatFloat(v, 'g', -1, bits)
+	if i := strings.IndexByte(s, 'e'); i >= 0 {
+		exp := strings.TrimLeft(strings.TrimPrefix(s[(i+1):], "+"), "0")
+		if strings.HasPrefix(exp, "-") {
+			exp = "-" + strings.TrimLeft(exp[1:], "0")
+		}
+		s = s[:(i+1)] + exp
+	}
+	return s
+}
+
+// bitValue returns the value in binary digits. The bits above whole
+// bytes are kept with the null bits at the start of the record.
+func (c *column) bitValue(d, rec []byte, bitPos int) string {
+	n := int(c.fieldLength) / 8
+	v := readBigEndian(d, n)
+	if l := uint(c.fieldLength) & 7; l > 0 {
+		b := uint64(rec[bitPos/8])
+		if bitPos%8+int(l) > 8 {
+			b |= uint64(rec[bitPos/8+1]) << 8
+		}
+		v |= ((b >> uint(bitPos%8)) & (1<<l - 1)) << uint(n*8)
+	}
+	return strconv.FormatUint(v, 2)
+}
+
+func (c *column) stringValue(b []byte) (string, error) {
+	return c.charsetA().Decode(b)
+}
+
+func (c *column) charValue(b []byte) (string, error) {
+	return c.charsetA().DecodeChar(b)
+}
+
+// fractionDigits returns the fractional seconds precision of a temporal
+// column from its length and the length without fractions.
+func (c *column) fractionDigits(width int) int {
+	if int(c.fieldLength) > width {
+		return int(c.fieldLength) - width - 1
+	}
+	return 0
+}
+
+// fraction reads the fractional seconds stored after the integer part
+// and returns them in microseconds.
+func fraction(d []byte, fsp int) int64 {
+	switch (fsp + 1) / 2 {
+	case 1:
+		return int64(d[0]) * 10000
+	case 2:
+		return int64(readBigEndian(d, 2)) * 100
+	case 3:
+		return int64(readBigEndian(d, 3))
+	}
+	return 0
+}
+
+func formatFraction(micro int64, fsp int) string {
+	if fsp == 0 {
+		return emptyString
+	}
+	return "." + fmt.Sprintf("%06d", micro)[:fsp]
+}
+
+func (c *column) time2Value(d []byte) string {
+	fsp := c.fractionDigits(timeWidth)
+	var packed int64
+	switch n := (fsp + 1) / 2; n {
+	case 0:
+		packed = (int64(readBigEndian(d, 3)) - timeIntOffset) << 24
+	case 1, 2:
+		// negative values borrow a second from the integer part
+		intPart := int64(readBigEndian(d, 3)) - timeIntOffset
+		frac := int64(readBigEndian(d[3:], n))
+		if intPart < 0 && frac != 0 {
+			intPart++
+			frac -= 1 << uint(n*8)
+		}
+		if n == 1 {
+			frac *= 10000
+		} else {
+			frac *= 100
+		}
+		packed = intPart<<24 + frac
+	default:
+		packed = int64(readBigEndian(d, 6)) - time6IntOffset
+	}
+	sign := emptyString
+	if packed < 0 {
+		sign, packed = "-", -packed
+	}
+	hms := packed >> 24
+	return fmt.Sprintf("%s%02d:%02d:%02d%s", sign, (hms>>12)%(1<<10), (hms>>6)%64, hms%64,
+		formatFraction(packed%(1<<24), fsp))
+}
+
+func (c *column) dateTime2Value(d []byte) string {
+	fsp := c.fractionDigits(dateTimeWidth)
+	v := int64(readBigEndian(d, 5)) - dateTimeIntOffset
+	ymd, hms := v>>17, v%(1<<17)
+	ym := ymd >> 5
+	return fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d%s", ym/13, ym%13, ymd%32,
+		hms>>12, (hms>>6)%64, hms%64, formatFraction(fraction(d[5:], fsp), fsp))
+}
+
+// timeStampValue formats seconds since the epoch in UTC, the server
+// uses the time zone of the session instead.
+func timeStampValue(sec, micro int64, fsp int) string {
+	if sec == 0 && micro == 0 {
+		return zeroDate + " " + zeroTime + formatFraction(0, fsp)
+	}
+	return time.Unix(sec, 0).UTC().Format("2006-01-02 15:04:05") + formatFraction(micro, fsp)
+}
+
+// readDecimal decodes the binary DECIMAL format: groups of nine digits
+// in four big endian bytes with the leading and trailing digits packed
+// into fewer bytes, the sign bit flipped and negative values inverted.
+func readDecimal(d []byte, precision, scale int) string {
+	intg := precision - scale
+	intg0, intg0x := intg/digitsPerDecimal, intg%digitsPerDecimal
+	frac0, frac0x := scale/digitsPerDecimal, scale%digitsPerDecimal
+	size := intg0*4 + decimalDigitBytes[intg0x] + frac0*4 + decimalDigitBytes[frac0x]
+	if size == 0 || size > len(d) {
+		return "0"
+	}
+	b := make([]byte, size)
+	copy(b, d)
+	neg := (b[0] & 0x80) == 0
+	b[0] ^= 0x80
+	if neg {
+		for i := range b {
+			b[i] ^= 0xff
+		}
+	}
+	digits := &strings.Builder{}
+	if n := decimalDigitBytes[intg0x]; n > 0 {
+		digits.WriteString(strconv.FormatUint(readBigEndian(b, n), 10))
+		b = b[n:]
+	}
+	for i := 0; i < intg0; i++ {
+		fmt.Fprintf(digits, "%09d", readBigEndian(b, 4))
+		b = b[4:]
+	}
+	s := strings.TrimLeft(digits.String(), "0")
+	if s == emptyString {
+		s = "0"
+	}
+	if scale > 0 {
+		digits.Reset()
+		for i := 0; i < frac0; i++ {
+			fmt.Fprintf(digits, "%09d", readBigEndian(b, 4))
+			b = b[4:]
+		}
+		if n := decimalDigitBytes[frac0x]; n > 0 {
+			fmt.Fprintf(digits, "%0*d", frac0x, readBigEndian(b, n))
+		}
+		s += "." + digits.String()
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
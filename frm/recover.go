@@ -0,0 +1,333 @@
+package frm
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultIoSize      = 4096
+	companionTrust     = 0.5
+	maxVersionId       = 200000
+	headerChecks       = 5
+	unknownTypeName    = "<UNKNOWN_TYPE>"
+	viewFilePrefix     = "TYPE=VIEW"
+	columnsSection     = "columns"
+	keysSection        = "keys"
+	headerSection      = "header"
+	extraSection       = "extra"
+	defaultsSection    = "defaults"
+	maxIoSize          = 65536
+	minIoSize          = 512
+	companionNoteLimit = 10
+)
+
+// Diagnostic tells how a section of a damaged file was read. Confidence
+// runs from 0, nothing was read, to 1, the section is consistent.
+type Diagnostic struct {
+	Section    string
+	Confidence float64
+	Messages   []string
+}
+
+func (d Diagnostic) String() string {
+	s := d.Section + " " + strconv.FormatFloat(d.Confidence, 'f', 2, 64)
+	if len(d.Messages) > 0 {
+		s += ": " + strings.Join(d.Messages, "; ")
+	}
+	return s
+}
+
+func (d *Diagnostic) note(format string, a ...interface{}) {
+	d.Messages = append(d.Messages, fmt.Sprintf(format, a...))
+}
+
+type recovery struct {
+	f           *Frm
+	data        []byte
+	path        string
+	companion   []column
+	source      string
+	lost        []string
+	diagnostics []Diagnostic
+}
+
+// safely runs a reader and turns a panic on damaged data into an error.
+func safely(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	fn()
+	return nil
+}
+
+// isName reports whether s can be an identifier the server wrote.
+func isName(s string) bool {
+	if s == emptyString || !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if r < ' ' || r == utf8.RuneError {
+			return false
+		}
+	}
+	return true
+}
+
+// RecoverFrm reads whatever is intact of a damaged .frm file and reports
+// each section with a confidence. Columns are checked against the .cfg
+// or the .ibd file of the table next to it, which replace the columns
+// that cannot be read. An error is returned only for files that are not
+// table definitions at all.
+func RecoverFrm(path string) (*Frm, []Diagnostic, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data) < frmStructSize || bytes.HasPrefix(data, []byte(viewFilePrefix)) {
+		return nil, nil, WrongFRMFileErr
+	}
+	r := &recovery{f: &Frm{}, data: data, path: path}
+	r.readCompanion()
+	r.readHeader()
+	r.readColumns()
+	r.readKeys()
+	r.readExtra()
+	r.readDefaults()
+	return r.f, r.diagnostics, nil
+}
+
+// readCompanion reads the columns of the .cfg file, or of the SDI of
+// the .ibd file.
+func (r *recovery) readCompanion() {
+	base := strings.TrimSuffix(r.path, "."+frmExt)
+	if t, err := readCfg(base + "." + cfgExt); err == nil {
+		dt := &DictTable{Frm: r.f}
+		r.companion = make([]column, len(t.columns))
+		for i := range t.columns {
+			dc := &t.columns[i]
+			r.companion[i].name = dc.name
+			dt.readDictColumn(&r.companion[i], dc)
+		}
+		r.source = base + "." + cfgExt
+		r.lost = dt.Lost
+		return
+	}
+	if sdi, err := NewSdi(base + "." + ibdExt); err == nil {
+		r.companion = sdi.columns
+		r.source = base + "." + ibdExt
+	}
+}
+
+func (r *recovery) readHeader() {
+	f := r.f
+	d := Diagnostic{Section: headerSection}
+	f.read(r.data)
+	f.readFormPos(r.data)
+	if f.formPos+formInfoSize >= len(r.data) {
+		d.note("form position %d is past the end of the file", f.formPos)
+		f.formPos = 0
+	}
+	passed := 0
+	if f.fileType == tableFileType {
+		passed++
+	} else {
+		d.note("file type %#04x is wrong", f.fileType)
+	}
+	if n := int(f.ioSize); n >= minIoSize && n <= maxIoSize && (n&(n-1)) == 0 {
+		passed++
+	} else {
+		d.note("io size %d is wrong, %d is used", n, defaultIoSize)
+		f.ioSize = defaultIoSize
+	}
+	if f.mySQLVersionId < maxVersionId {
+		passed++
+	} else {
+		d.note("server version %d is wrong", f.mySQLVersionId)
+		f.mySQLVersionId = 0
+	}
+	if f.recordPos()+int(f.recLength) <= len(r.data) {
+		passed++
+	} else {
+		d.note("default record is past the end of the file")
+	}
+	if f.columnsPos() < len(r.data) {
+		passed++
+	} else {
+		d.note("column info is past the end of the file")
+	}
+	d.Confidence = float64(passed) / headerChecks
+	r.diagnostics = append(r.diagnostics, d)
+}
+
+// isBadColumn reports why a column that was read cannot be right.
+func (r *recovery) isBadColumn(c *column) string {
+	b := &strings.Builder{}
+	c.writeShowType(b, Version{})
+	switch {
+	case !isName(c.name):
+		return "name " + strconv.Quote(c.name) + " is wrong"
+	case strings.Contains(b.String(), unknownTypeName):
+		return "type " + strconv.Itoa(int(c.fieldType)) + " is unknown"
+	case c.hasCharset() && !c.knownCharset():
+		return "character set " + strconv.Itoa(c.charsetNum()) + " is unknown"
+	case c.recPos > int(r.f.recLength):
+		return "record position " + strconv.Itoa(c.recPos) + " is past the record"
+	}
+	return emptyString
+}
+
+func (r *recovery) readColumns() {
+	f := r.f
+	d := Diagnostic{Section: columnsSection, Confidence: 1}
+	defer func() {
+		r.diagnostics = append(r.diagnostics, d)
+	}()
+	if err := safely(func() { f.readColumns(r.data) }); err != nil {
+		f.columns = nil
+		d.Confidence = 0
+		d.note("column info is damaged: %v", err)
+		if r.companion != nil {
+			f.columns = append([]column(nil), r.companion...)
+			d.Confidence = companionTrust
+			d.note("columns are taken from %s", r.source)
+			r.noteLost(&d)
+		}
+		return
+	}
+	f.fixOldColumns()
+	if r.companion != nil && len(r.companion) != len(f.columns) {
+		d.note("%s has %d columns, the .frm file %d", r.source, len(r.companion), len(f.columns))
+	}
+	good := 0.0
+	for i := range f.columns {
+		c := &f.columns[i]
+		why := r.isBadColumn(c)
+		if why == emptyString {
+			good++
+			if i < len(r.companion) && r.companion[i].name != c.name {
+				d.note("column %d is %s, %s in %s", i, QuoteIdent(c.name), QuoteIdent(r.companion[i].name), r.source)
+			}
+			continue
+		}
+		if i >= len(r.companion) {
+			d.note("column %d: %s", i, why)
+			r.repairColumn(c)
+			continue
+		}
+		recPos := c.recPos
+		*c = r.companion[i]
+		if recPos <= int(f.recLength) {
+			c.recPos = recPos
+		}
+		good += companionTrust
+		d.note("column %d: %s, %s is taken from %s", i, why, QuoteIdent(c.name), r.source)
+	}
+	if len(f.columns) > 0 {
+		d.Confidence = good / float64(len(f.columns))
+	}
+}
+
+// repairColumn makes a damaged column printable when there is nothing to
+// take it from.
+func (r *recovery) repairColumn(c *column) {
+	if !isName(c.name) {
+		c.name = "col" + strconv.Itoa(c.recPos)
+	}
+	if c.hasCharset() && !c.knownCharset() {
+		c.charset = binaryCharset
+		c.charsetLow = 0
+	}
+	if c.recPos > int(r.f.recLength) {
+		c.recPos = 0
+	}
+}
+
+func (r *recovery) noteLost(d *Diagnostic) {
+	for i, s := range r.lost {
+		if i == companionNoteLimit {
+			d.note("%d more", len(r.lost)-i)
+			return
+		}
+		d.Messages = append(d.Messages, s)
+	}
+}
+
+func (r *recovery) readKeys() {
+	f := r.f
+	d := Diagnostic{Section: keysSection, Confidence: 1}
+	defer func() {
+		r.diagnostics = append(r.diagnostics, d)
+	}()
+	if err := safely(func() { f.readKeys(r.data) }); err != nil {
+		f.keys = nil
+		d.Confidence = 0
+		d.note("key info is damaged, keys are dropped: %v", err)
+		return
+	}
+	keys := f.keys[:0]
+	total := len(f.keys)
+	for i, k := range f.keys {
+		ok := isName(k.name) && len(k.parts) > 0
+		for _, p := range k.parts {
+			if n := p.fieldNumA(); n < 0 || n >= len(f.columns) {
+				ok = false
+			}
+		}
+		if !ok {
+			d.note("key %d %s is damaged and dropped", i, strconv.Quote(k.name))
+			continue
+		}
+		keys = append(keys, k)
+	}
+	f.keys = keys
+	if total > 0 {
+		d.Confidence = float64(len(keys)) / float64(total)
+	}
+}
+
+func (r *recovery) readExtra() {
+	f := r.f
+	d := Diagnostic{Section: extraSection, Confidence: 1}
+	if err := safely(func() { f.readExtra(r.data) }); err != nil {
+		f.connectString, f.engineName, f.partitionInfo, f.comment = emptyString, emptyString, emptyString, emptyString
+		d.Confidence = 0
+		d.note("extra segment is damaged: %v", err)
+	}
+	if f.engineName != emptyString && !isName(f.engineName) {
+		d.note("engine name %s is wrong", strconv.Quote(f.engineName))
+		f.engineName = emptyString
+		d.Confidence /= 2
+	}
+	if f.Engine() == emptyString && r.source != emptyString {
+		f.engineName = innoDBEngine
+		d.note("engine InnoDB is taken from %s", r.source)
+	}
+	if f.tableCharsetNum() != 0 && f.tableCharset() == nil {
+		d.note("table character set %d is unknown", f.tableCharsetNum())
+		f.defaultCharset, f.charsetLow = 0, 0
+	}
+	r.diagnostics = append(r.diagnostics, d)
+}
+
+func (r *recovery) readDefaults() {
+	f := r.f
+	d := Diagnostic{Section: defaultsSection, Confidence: 1}
+	var err error
+	if perr := safely(func() { err = f.readDefaults(r.data) }); perr != nil {
+		for i := range f.columns {
+			f.columns[i].hasDefault = false
+		}
+		d.Confidence = 0
+		d.note("default record is damaged, defaults are dropped: %v", perr)
+	} else if err != nil {
+		d.note("defaults that cannot be decoded are dropped: %v", err)
+	}
+	r.diagnostics = append(r.diagnostics, d)
+}
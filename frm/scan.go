@@ -0,0 +1,227 @@
+package frm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SchemaSet holds the table definitions of a database.
+type SchemaSet struct {
+	Database string
+	Dir      string
+	Tables   map[string]*Frm
+}
+
+// ScanError is the error of a file the scanner could not parse.
+type ScanError struct {
+	Path string
+	Err  error
+}
+
+func (e *ScanError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+// ScanResult holds the databases found by a scan and the errors of the
+// files that could not be parsed, sorted by path.
+type ScanResult struct {
+	Schemas map[string]*SchemaSet
+	Errors  []*ScanError
+}
+
+// Scanner parses the table definitions of data directories with a pool
+// of workers. Parsed files are cached by path, modification time and
+// size, so scanning again parses only the files that changed, and the
+// files gone from a scanned directory are dropped from the cache. A
+// Scanner may be used by several goroutines.
+type Scanner struct {
+	workers int
+	mu      sync.Mutex
+	cache   map[string]*scanEntry
+}
+
+type scanEntry struct {
+	modTime time.Time
+	size    int64
+	frm     *Frm
+	err     error
+}
+
+type scanJob struct {
+	schema *SchemaSet
+	table  string
+	path   string
+	info   os.FileInfo
+}
+
+// NewScanner returns a scanner with the given number of workers, one
+// per CPU when workers is not positive.
+func NewScanner(workers int) *Scanner {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return &Scanner{workers: workers, cache: make(map[string]*scanEntry)}
+}
+
+// Scan parses the tables of every database directory of a data
+// directory.
+func (s *Scanner) Scan(dataDir string) (*ScanResult, error) {
+	dirs, err := ioutil.ReadDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make([]*SchemaSet, 0, len(dirs))
+	errs := make([]*ScanError, 0)
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		database, err := DecodeFilename(dir.Name())
+		if err != nil {
+			errs = append(errs, &ScanError{Path: filepath.Join(dataDir, dir.Name()), Err: err})
+			continue
+		}
+		schemas = append(schemas, &SchemaSet{Database: database, Dir: filepath.Join(dataDir, dir.Name())})
+	}
+	return s.scan(dataDir, schemas, errs)
+}
+
+// ScanDir parses the tables of the database directory dir.
+func (s *Scanner) ScanDir(dir, database string) (*ScanResult, error) {
+	return s.scan(dir, []*SchemaSet{{Database: database, Dir: dir}}, make([]*ScanError, 0))
+}
+
+// scan parses the tables of schemas. Errors are those of the files found
+// before, like directories with names that cannot be decoded.
+func (s *Scanner) scan(root string, schemas []*SchemaSet, errs []*ScanError) (*ScanResult, error) {
+	res := &ScanResult{Schemas: make(map[string]*SchemaSet), Errors: errs}
+	jobs := make([]scanJob, 0)
+	for _, schema := range schemas {
+		list, err := scanJobs(schema, res)
+		if err != nil {
+			return nil, err
+		}
+		schema.Tables = make(map[string]*Frm)
+		res.Schemas[schema.Database] = schema
+		jobs = append(jobs, list...)
+	}
+	ch := make(chan scanJob)
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range ch {
+				f, err := s.parse(job.path, job.info)
+				mu.Lock()
+				switch {
+				case err == nil:
+					job.schema.Tables[job.table] = f
+				case err != WrongFRMFileErr && err != NoSDIErr:
+					res.Errors = append(res.Errors, &ScanError{Path: job.path, Err: err})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, job := range jobs {
+		ch <- job
+	}
+	close(ch)
+	wg.Wait()
+	s.evict(root, jobs)
+	sort.Slice(res.Errors, func(i, j int) bool { return res.Errors[i].Path < res.Errors[j].Path })
+	return res, nil
+}
+
+// evict drops the cached files under root that were not scanned.
+func (s *Scanner) evict(root string, jobs []scanJob) {
+	seen := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		seen[job.path] = true
+	}
+	prefix := filepath.Clean(root) + string(filepath.Separator)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for path := range s.cache {
+		if strings.HasPrefix(path, prefix) && !seen[path] {
+			delete(s.cache, path)
+		}
+	}
+}
+
+// scanJobs lists the files to parse in a database directory: the .frm
+// files and, for tables without one, the first .ibd file. Views and
+// MySQL 5.x tablespaces are skipped later, when parsing them fails. The
+// table files with names that cannot be decoded are errors of res.
+func scanJobs(schema *SchemaSet, res *ScanResult) ([]scanJob, error) {
+	files, err := ioutil.ReadDir(schema.Dir)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make(map[string]scanJob)
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		tf, err := ParseTableFile(file.Name())
+		if err != nil {
+			if ext := filepath.Ext(file.Name()); ext == "."+frmExt || ext == "."+ibdExt {
+				res.Errors = append(res.Errors, &ScanError{Path: filepath.Join(schema.Dir, file.Name()), Err: err})
+			}
+			continue
+		}
+		if tf.Temp {
+			continue
+		}
+		job := scanJob{schema: schema, table: tf.Table, path: filepath.Join(schema.Dir, file.Name()), info: file}
+		switch tf.Ext {
+		case frmExt:
+			jobs[tf.Table] = job
+		case ibdExt:
+			if _, ok := jobs[tf.Table]; !ok {
+				jobs[tf.Table] = job
+			}
+		}
+	}
+	list := make([]scanJob, 0, len(jobs))
+	for _, job := range jobs {
+		list = append(list, job)
+	}
+	return list, nil
+}
+
+// parse returns the cached result for the file when it did not change.
+// A damaged file is an error rather than a panic of the worker.
+func (s *Scanner) parse(path string, info os.FileInfo) (*Frm, error) {
+	s.mu.Lock()
+	e := s.cache[path]
+	s.mu.Unlock()
+	if e != nil && e.modTime.Equal(info.ModTime()) && e.size == info.Size() {
+		return e.frm, e.err
+	}
+	e = &scanEntry{modTime: info.ModTime(), size: info.Size()}
+	if err := safely(func() {
+		if filepath.Ext(path) == "."+ibdExt {
+			e.frm, e.err = NewSdi(path)
+		} else {
+			e.frm, e.err = NewFrm(path)
+		}
+	}); err != nil {
+		e.frm, e.err = nil, err
+	}
+	if os.IsNotExist(e.err) {
+		return nil, e.err
+	}
+	s.mu.Lock()
+	s.cache[path] = e
+	s.mu.Unlock()
+	return e.frm, e.err
+}
@@ -0,0 +1,470 @@
+package frm
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	WrongIBDFileErr = errors.New("Wrong IBD file type.")
+	NoSDIErr        = errors.New("No SDI found in IBD file.")
+)
+
+type sdiElement struct {
+	Length    int  `json:"length"`
+	Order     int  `json:"order"`
+	Hidden    bool `json:"hidden"`
+	ColumnOpx int  `json:"column_opx"`
+}
+
+type sdiIndex struct {
+	Name      string       `json:"name"`
+	Hidden    bool         `json:"hidden"`
+	Type      int          `json:"type"`
+	Algorithm int          `json:"algorithm"`
+	Explicit  bool         `json:"is_algorithm_explicit"`
+	Comment   string       `json:"comment"`
+	Options   string       `json:"options"`
+	Elements  []sdiElement `json:"elements"`
+}
+
+type sdiValue struct {
+	Name string `json:"name"`
+}
+
+type sdiColumn struct {
+	Name            string     `json:"name"`
+	Type            int        `json:"type"`
+	IsNullable      bool       `json:"is_nullable"`
+	IsUnsigned      bool       `json:"is_unsigned"`
+	IsZerofill      bool       `json:"is_zerofill"`
+	IsAutoIncrement bool       `json:"is_auto_increment"`
+	Hidden          int        `json:"hidden"`
+	CharLength      int        `json:"char_length"`
+	NumericScale    int        `json:"numeric_scale"`
+	ColumnTypeUtf8  string     `json:"column_type_utf8"`
+	CollationId     int        `json:"collation_id"`
+	HasNoDefault    bool       `json:"has_no_default"`
+	DefaultNull     bool       `json:"default_value_utf8_null"`
+	DefaultValue    string     `json:"default_value_utf8"`
+	DefaultOption   string     `json:"default_option"`
+	UpdateOption    string     `json:"update_option"`
+	Comment         string     `json:"comment"`
+	Expression      string     `json:"generation_expression_utf8"`
+	Elements        []sdiValue `json:"elements"`
+}
+
+type sdiTable struct {
+	Name        string      `json:"name"`
+	Engine      string      `json:"engine"`
+	Comment     string      `json:"comment"`
+	Options     string      `json:"options"`
+	CollationId int         `json:"collation_id"`
+	Columns     []sdiColumn `json:"columns"`
+	Indexes     []sdiIndex  `json:"indexes"`
+}
+
+type sdi struct {
+	MysqldVersionId uint32          `json:"mysqld_version_id"`
+	ObjectType      string          `json:"dd_object_type"`
+	Object          json.RawMessage `json:"dd_object"`
+}
+
+// Columns hidden from SQL are those of functional key parts, their
+// expressions are written as the key parts. Columns hidden by the user
+// are INVISIBLE.
+const (
+	sdiVisibleColumn       = 1
+	sdiSQLHiddenColumn     = 3
+	sdiUserInvisibleColumn = 4
+)
+
+const (
+	sdiPrimaryIndex  = 1
+	sdiUniqueIndex   = 2
+	sdiMultipleIndex = 3
+	sdiFullTextIndex = 4
+	sdiSpatialIndex  = 5
+)
+
+const (
+	sdiDescOrder = 3
+)
+
+const (
+	sdiBTreeAlgo    = 2
+	sdiRTreeAlgo    = 3
+	sdiHashAlgo     = 4
+	sdiFullTextAlgo = 5
+)
+
+var (
+	sdiFieldTypes = map[int]uint8{
+		1:  decimalFieldType,
+		2:  tinyFieldType,
+		3:  shortFieldType,
+		4:  longFieldType,
+		5:  floatFieldType,
+		6:  doubleFieldType,
+		7:  nullFieldType,
+		8:  timeStampFieldType,
+		9:  longLongFieldType,
+		10: int24FieldType,
+		11: dateFieldType,
+		12: timeFieldType,
+		13: dateTimeFieldType,
+		14: yearFieldType,
+		15: newDateFieldType,
+		16: varCharFieldType,
+		17: bitFieldType,
+		18: timeStamp2FieldType,
+		19: dateTime2FieldType,
+		20: time2FieldType,
+		21: newDecimalFieldType,
+		22: enumFieldType,
+		23: setFieldType,
+		24: tinyBlobFieldType,
+		25: mediumBlobFieldType,
+		26: longBlobFieldType,
+		27: blobFieldType,
+		28: varStringFieldType,
+		29: stringFieldType,
+		30: geometryFieldType,
+		31: jsonFieldType,
+	}
+	sdiGeomTypes = map[string]int{
+		"geometry":           geometryGeomType,
+		"point":              pointGeomType,
+		"linestring":         lineStringGeomType,
+		"polygon":            polygonGeomType,
+		"multipoint":         multiPointGeomType,
+		"multilinestring":    multiLineStrintGeomType,
+		"multipolygon":       multiPolygonGeomType,
+		"geometrycollection": geometryCollectionGeomType,
+		"geomcollection":     geometryCollectionGeomType,
+	}
+)
+
+// NewSdi reads the table definition embedded as Serialized Dictionary
+// Information in a MySQL 8.0 .ibd file.
+func NewSdi(path string) (*Frm, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	var frm *Frm
+	err = readSdiRecords(file, info.Size(), func(rec []byte) (bool, error) {
+		s := &sdi{}
+		if err := json.Unmarshal(rec, s); err != nil {
+			return false, err
+		}
+		if s.ObjectType != "Table" {
+			return false, nil
+		}
+		t := &sdiTable{}
+		if err := json.Unmarshal(s.Object, t); err != nil {
+			return false, err
+		}
+		frm = &Frm{mySQLVersionId: s.MysqldVersionId}
+		frm.readSdi(t)
+		return true, frm.checkCharsets()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if frm == nil {
+		return nil, NoSDIErr
+	}
+	return frm, nil
+}
+
+// readSdiRecords calls fn with the SDI records of a tablespace of size
+// bytes, reading one page at a time, until fn returns true.
+func readSdiRecords(r io.ReaderAt, size int64, fn func([]byte) (bool, error)) error {
+	ts, err := newTablespace(r, WrongIBDFileErr)
+	if err != nil {
+		return err
+	}
+	if (ts.flags & sdiFlag) == 0 {
+		return NoSDIErr
+	}
+	pageSize := ts.pageSize
+	for n := uint32(0); int64(n+1)*int64(pageSize) <= size; n++ {
+		page, err := ts.page(n)
+		if err != nil {
+			return err
+		}
+		if binary.BigEndian.Uint16(page[filPageType:]) != sdiPageType {
+			continue
+		}
+		if binary.BigEndian.Uint16(page[pageLevel:]) != 0 {
+			continue
+		}
+		k := 0
+		for origin := nextRecord(page, pageNewInfimum); origin != pageNewSupremum; origin = nextRecord(page, origin) {
+			k++
+			if origin <= pageNewSupremum || origin >= pageSize || k > pageSize {
+				return WrongIBDFileErr
+			}
+			if (page[origin-5] & recordDeletedFlag) != 0 {
+				continue
+			}
+			if (page[origin-3] & recordStatusMask) != 0 {
+				continue
+			}
+			if origin+sdiRecordDataPos > pageSize {
+				return WrongIBDFileErr
+			}
+			if binary.BigEndian.Uint32(page[origin:]) != sdiTableType {
+				continue
+			}
+			rec, err := readSdiRecord(ts, page, origin)
+			if err != nil {
+				return err
+			}
+			if done, err := fn(rec); done || err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func nextRecord(page []byte, origin int) int {
+	offset := int(int16(binary.BigEndian.Uint16(page[(origin - 2):])))
+	return (origin + offset) & (len(page) - 1)
+}
+
+func readSdiRecord(ts *tablespace, page []byte, origin int) ([]byte, error) {
+	pageSize := ts.pageSize
+	uncompLen := int(binary.BigEndian.Uint32(page[(origin + 25):]))
+	compLen := int(binary.BigEndian.Uint32(page[(origin + 29):]))
+	fieldLen := int(page[origin-6])
+	external := false
+	if (fieldLen & 0x80) != 0 {
+		external = (fieldLen & 0x40) != 0
+		fieldLen = ((fieldLen & 0x3f) << 8) | int(page[origin-7])
+	}
+	start := origin + sdiRecordDataPos
+	if start+fieldLen > pageSize {
+		return nil, WrongIBDFileErr
+	}
+	comp := &bytes.Buffer{}
+	if external {
+		if fieldLen < blobRefSize {
+			return nil, WrongIBDFileErr
+		}
+		comp.Write(page[start:(start + fieldLen - blobRefSize)])
+		ref := page[(start + fieldLen - blobRefSize):(start + fieldLen)]
+		pageNo := binary.BigEndian.Uint32(ref[4:8])
+		offset := int(binary.BigEndian.Uint32(ref[8:12]))
+		for pageNo != filNull && comp.Len() < compLen {
+			blob, err := ts.page(pageNo)
+			if err != nil {
+				return nil, err
+			}
+			switch binary.BigEndian.Uint16(blob[filPageType:]) {
+			case sdiBlobPageType, blobPageType:
+			default:
+				return nil, WrongIBDFileErr
+			}
+			if offset < 0 || offset+8 > pageSize {
+				return nil, WrongIBDFileErr
+			}
+			partLen := int(binary.BigEndian.Uint32(blob[offset:]))
+			if offset+8+partLen > pageSize {
+				return nil, WrongIBDFileErr
+			}
+			comp.Write(blob[(offset + 8):(offset + 8 + partLen)])
+			pageNo = binary.BigEndian.Uint32(blob[(offset + 4):])
+			offset = filPageData
+		}
+	} else {
+		comp.Write(page[start:(start + fieldLen)])
+	}
+	if comp.Len() != compLen {
+		return nil, WrongIBDFileErr
+	}
+	r, err := zlib.NewReader(comp)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	rec, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(rec) != uncompLen {
+		return nil, WrongIBDFileErr
+	}
+	return rec, nil
+}
+
+func (f *Frm) readSdi(t *sdiTable) {
+	f.fileType = tableFileType
+	f.defaultCharset = uint8(t.CollationId)
+	f.charsetLow = uint8(t.CollationId >> 8)
+	f.engineName = t.Engine
+	f.comment = t.Comment
+	options := sdiOptions(t.Options)
+	if n, err := strconv.Atoi(options["row_type"]); err == nil {
+		f.rowType = uint8(n)
+	}
+	if n, err := strconv.Atoi(options["key_block_size"]); err == nil {
+		f.keyBlockSize = uint16(n)
+	}
+	opx := make(map[int]int)
+	exprs := make(map[int]string)
+	f.columns = make([]column, 0, len(t.Columns))
+	for i := range t.Columns {
+		sc := &t.Columns[i]
+		if sc.Hidden == sdiSQLHiddenColumn && sc.Expression != emptyString {
+			exprs[i] = sc.Expression
+		}
+		if sc.Hidden != sdiVisibleColumn && sc.Hidden != sdiUserInvisibleColumn {
+			continue
+		}
+		opx[i] = len(f.columns)
+		f.columns = append(f.columns, column{})
+		f.columns[len(f.columns)-1].readSdi(sc)
+	}
+	f.keys = make([]key, 0, len(t.Indexes))
+	for i := range t.Indexes {
+		si := &t.Indexes[i]
+		if si.Hidden {
+			continue
+		}
+		k := key{name: si.Name}
+		k.readSdi(si, opx, exprs, f.columns)
+		f.keys = append(f.keys, k)
+	}
+}
+
+func (c *column) readSdi(sc *sdiColumn) {
+	c.name = sc.Name
+	c.fieldType = sdiFieldTypes[sc.Type]
+	c.fieldLength = uint16(sc.CharLength)
+	c.charset = uint8(sc.CollationId)
+	c.charsetLow = uint8(sc.CollationId >> 8)
+	if sc.IsNullable {
+		c.flags |= nullableFieldFlag
+	}
+	if !sc.IsUnsigned {
+		c.flags |= signedFieldFlag
+	}
+	switch c.fieldType {
+	case newDecimalFieldType, floatFieldType, doubleFieldType:
+		c.flags |= uint16(sc.NumericScale&decimalMask) << decimalShift
+	case geometryFieldType:
+		geomType := sdiGeomTypes[strings.ToLower(sc.ColumnTypeUtf8)]
+		c.charset = uint8(geomType)
+		c.charsetLow = 0
+	}
+	if sc.IsZerofill {
+		c.flags |= zeroFillFieldFlag
+	}
+	if sc.HasNoDefault {
+		c.flags |= noDefaultFieldFlag
+	}
+	c.hasDefault = !sc.DefaultNull && !sc.HasNoDefault
+	c.defaultValue = strings.TrimSuffix(strings.TrimPrefix(sc.DefaultValue, "b'"), "'")
+	c.comment = sc.Comment
+	c.invisible = sc.Hidden == sdiUserInvisibleColumn
+	for _, e := range sc.Elements {
+		b, _ := base64.StdEncoding.DecodeString(e.Name)
+		c.intervals = append(c.intervals, string(b))
+	}
+	switch {
+	case sc.IsAutoIncrement:
+		c.uniregType = nextNumberUnireg
+	case sc.DefaultOption != emptyString && sc.UpdateOption != emptyString:
+		c.uniregType = timeStampDnUnUnireg
+	case sc.DefaultOption != emptyString:
+		c.uniregType = timeStampDnUnireg
+	case sc.UpdateOption != emptyString:
+		c.uniregType = timeStampUnUnireg
+	}
+}
+
+func (k *key) readSdi(si *sdiIndex, opx map[int]int, exprs map[int]string, columns []column) {
+	switch si.Type {
+	case sdiMultipleIndex:
+		k.flags |= allowDupsKeyFlag
+	case sdiFullTextIndex:
+		k.flags |= allowDupsKeyFlag | fullTextKeyFlag
+	case sdiSpatialIndex:
+		k.flags |= allowDupsKeyFlag | spatialKeyFlag
+	}
+	k.comment = si.Comment
+	if si.Comment != emptyString {
+		k.flags |= usesCommentKeyFlag
+	}
+	options := sdiOptions(si.Options)
+	if parser, ok := options["parser_name"]; ok {
+		k.parser = parser
+		k.flags |= usesParserKeyFlag
+	}
+	if bs, err := strconv.Atoi(options["block_size"]); err == nil && bs > 0 {
+		k.blockSize = uint16(bs)
+		k.flags |= usesBlockSizeKeyFlag
+	}
+	switch {
+	case !si.Explicit && si.Type != sdiFullTextIndex:
+	case si.Algorithm == sdiBTreeAlgo:
+		k.algorithm = bTreeKeyAlgo
+	case si.Algorithm == sdiRTreeAlgo:
+		k.algorithm = rTreeKeyAlgo
+	case si.Algorithm == sdiHashAlgo:
+		k.algorithm = hashKeyAlgo
+	case si.Algorithm == sdiFullTextAlgo:
+		k.algorithm = fullTextKeyAlgo
+	}
+	k.parts = make([]part, 0, len(si.Elements))
+	for _, e := range si.Elements {
+		if e.Hidden {
+			continue
+		}
+		p := part{}
+		if expr, ok := exprs[e.ColumnOpx]; ok {
+			p.expr = expr
+		} else if n, ok := opx[e.ColumnOpx]; ok {
+			length := e.Length
+			if length > int(columns[n].fieldLength) {
+				length = int(columns[n].fieldLength)
+			}
+			p.fieldNum, p.length = uint16(n+1), uint16(length)
+		} else {
+			continue
+		}
+		if e.Order == sdiDescOrder {
+			p.keyPartFlag |= reverseSortPartFlag
+		}
+		k.parts = append(k.parts, p)
+	}
+	k.numParts = uint8(len(k.parts))
+}
+
+// sdiOptions splits an SDI options string like "a=1;b=2;".
+func sdiOptions(s string) map[string]string {
+	options := make(map[string]string)
+	for _, o := range strings.Split(s, ";") {
+		if i := strings.IndexByte(o, '='); i > 0 {
+			options[o[:i]] = o[(i + 1):]
+		}
+	}
+	return options
+}
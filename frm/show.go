@@ -0,0 +1,534 @@
+package frm
+
+import (
+	"encoding/hex"
+	"io"
+	"strings"
+)
+
+const (
+	innoDBEngine    = "InnoDB"
+	partitionEngine = "partition"
+	partitionDbType = 20
+)
+
+var (
+	// engines of the legacy_db_type enumeration
+	legacyEngines = map[uint8]string{
+		6:  "MEMORY",
+		9:  "MyISAM",
+		10: "MRG_MYISAM",
+		12: innoDBEngine,
+		14: "ndbcluster",
+		15: "EXAMPLE",
+		16: "ARCHIVE",
+		17: "CSV",
+		18: "FEDERATED",
+		19: "BLACKHOLE",
+		27: "Aria",
+		28: "PERFORMANCE_SCHEMA",
+	}
+	rowFormats = []string{
+		emptyString,
+		"FIXED",
+		"DYNAMIC",
+		"COMPRESSED",
+		"REDUNDANT",
+		"COMPACT",
+		"PAGE",
+	}
+	geomTypes = []string{
+		"geometry",
+		"point",
+		"linestring",
+		"polygon",
+		"multipoint",
+		"multilinestring",
+		"multipolygon",
+		"geometrycollection",
+	}
+	// types of the numbers MariaDB prints unquoted defaults of
+	numericTypes = map[string]bool{
+		"tinyint":   true,
+		"smallint":  true,
+		"mediumint": true,
+		"int":       true,
+		"bigint":    true,
+		"float":     true,
+		"double":    true,
+		"decimal":   true,
+		"year":      true,
+	}
+	doubledQuoteReplacer = strings.NewReplacer(
+		"\\", "\\\\",
+		"'", "''",
+		"\x00", "\\0",
+		"\n", "\\n",
+		"\r", "\\r",
+		"\x1a", "\\Z",
+	)
+)
+
+// Engine returns the storage engine of the table. The engine of the
+// partitions is returned for partitioned tables.
+func (f *Frm) Engine() string {
+	if f.engineName != emptyString && f.engineName != partitionEngine {
+		return f.engineName
+	}
+	dbType := f.legacyDbType
+	if dbType == partitionDbType || f.engineName == partitionEngine {
+		dbType = f.defaultPartDbType
+	}
+	return legacyEngines[dbType]
+}
+
+// quoteShow quotes a string literal the way v does: MySQL 8.0 doubles
+// single quotes where older releases escape them.
+func quoteShow(s string, v Version) string {
+	if v.IsMySQL80() {
+		return "'" + doubledQuoteReplacer.Replace(s) + "'"
+	}
+	return QuoteString(s)
+}
+
+// charsetName returns the name of a character set or collation as v
+// prints it.
+func charsetName(name string, v Version) string {
+	if v.hasUtf8mb3() && (name == "utf8" || strings.HasPrefix(name, "utf8_")) {
+		return utf8mb3Prefix + strings.TrimPrefix(name, "utf8")
+	}
+	return name
+}
+
+// isMySQL80Default reports whether cs is utf8mb4_0900_ai_ci, which MySQL
+// 8.0 names explicitly although it is the primary collation.
+func isMySQL80Default(cs *Charset, v Version) bool {
+	id, ok := defaultCollates80[cs.Name]
+	return ok && cs.Id == id && v.IsMySQL80()
+}
+
+// WriteShowCreateTable writes the table definition formatted exactly as
+// SHOW CREATE TABLE of the release v prints it. The AUTO_INCREMENT
+// counter is kept by the engine and is not written, TIMESTAMP defaults
+// are written in UTC.
+func (f *Frm) WriteShowCreateTable(w io.Writer, table string, v Version) {
+	writeString(w, "CREATE TABLE ")
+	writeQuoted(w, table)
+	writeString(w, " (")
+	for i := range f.columns {
+		if i > 0 {
+			writeComma(w)
+		}
+		writeString(w, "\n  ")
+		writeColumn(w, f.columns[i].modelFor(v), f.tableCharset(), v)
+	}
+	for i := range f.keys {
+		writeString(w, ",\n  ")
+		writeKey(w, f.keys[i].model(f), v)
+	}
+	writeString(w, "\n)")
+	f.writeShowOptions(w, v)
+}
+
+func (f *Frm) writeShowOptions(w io.Writer, v Version) {
+	if engine := f.Engine(); engine != emptyString {
+		writeString(w, " ENGINE=")
+		writeString(w, engine)
+	}
+	if cs := f.tableCharset(); cs != nil {
+		writeString(w, " DEFAULT CHARSET=")
+		writeString(w, charsetName(cs.Name, v))
+		if !cs.IsPrimary(v.Id) || isMySQL80Default(cs, v) {
+			writeString(w, " COLLATE=")
+			writeString(w, charsetName(cs.Collate, v))
+		}
+	}
+	if f.minRows > 0 {
+		writeString(w, " MIN_ROWS=")
+		writeNumber(w, int(f.minRows))
+	}
+	if f.maxRows > 0 {
+		writeString(w, " MAX_ROWS=")
+		writeNumber(w, int(f.maxRows))
+	}
+	if f.avgRowLength > 0 {
+		writeString(w, " AVG_ROW_LENGTH=")
+		writeNumber(w, int(f.avgRowLength))
+	}
+	if (f.tableOptions & packKeysOption) != 0 {
+		writeString(w, " PACK_KEYS=1")
+	}
+	if (f.tableOptions & noPackKeysOption) != 0 {
+		writeString(w, " PACK_KEYS=0")
+	}
+	if (f.tableOptions & statsPersistentOption) != 0 {
+		writeString(w, " STATS_PERSISTENT=1")
+	}
+	if (f.tableOptions & noStatsPersistentOption) != 0 {
+		writeString(w, " STATS_PERSISTENT=0")
+	}
+	switch f.statAutoRecalc {
+	case statsAutoRecalcOn:
+		writeString(w, " STATS_AUTO_RECALC=1")
+	case statsAutoRecalcOff:
+		writeString(w, " STATS_AUTO_RECALC=0")
+	}
+	if f.statSamplePages > 0 {
+		writeString(w, " STATS_SAMPLE_PAGES=")
+		writeNumber(w, int(f.statSamplePages))
+	}
+	if (f.tableOptions & checksumOption) != 0 {
+		writeString(w, " CHECKSUM=1")
+	}
+	if (f.tableOptions & delayKeyWriteOption) != 0 {
+		writeString(w, " DELAY_KEY_WRITE=1")
+	}
+	if n := int(f.rowType); n > 0 && n < len(rowFormats) {
+		writeString(w, " ROW_FORMAT=")
+		writeString(w, rowFormats[n])
+	}
+	if f.keyBlockSize > 0 {
+		writeString(w, " KEY_BLOCK_SIZE=")
+		writeNumber(w, int(f.keyBlockSize))
+	}
+	f.writeShowEngineOptions(w)
+	if f.comment != emptyString {
+		writeString(w, " COMMENT=")
+		writeString(w, quoteShow(f.comment, v))
+	}
+	if f.connectString != emptyString {
+		writeString(w, " CONNECTION=")
+		writeString(w, quoteShow(f.connectString, v))
+	}
+	f.writeShowDirectories(w, v)
+	if f.partitionInfo != emptyString {
+		writeString(w, "\n/*!50100")
+		writeString(w, f.partitionInfo)
+		writeString(w, " */")
+	}
+}
+
+func (c *column) isNumeric() bool {
+	switch c.fieldType {
+	case decimalFieldType,
+		tinyFieldType,
+		shortFieldType,
+		longFieldType,
+		floatFieldType,
+		doubleFieldType,
+		longLongFieldType,
+		int24FieldType,
+		yearFieldType,
+		newDecimalFieldType:
+		return true
+	}
+	return false
+}
+
+func (c *column) isBlob() bool {
+	switch c.fieldType {
+	case tinyBlobFieldType,
+		mediumBlobFieldType,
+		longBlobFieldType,
+		blobFieldType:
+		return true
+	}
+	return false
+}
+
+func (c *column) isTimeStamp() bool {
+	return c.fieldType == timeStampFieldType || c.fieldType == timeStamp2FieldType
+}
+
+// typeName returns the name of a type as SHOW CREATE TABLE prints it,
+// without its length and attributes.
+func typeName(t string) string {
+	if i := strings.IndexAny(t, "( "); i >= 0 {
+		return t[:i]
+	}
+	return t
+}
+
+// writeColumn writes a column definition as SHOW CREATE TABLE of the
+// release v prints it, the model being made for v. The character set is
+// written when it differs from the table one t, nil for none.
+func writeColumn(w io.Writer, m Column, t *Charset, v Version) {
+	name := typeName(m.Type)
+	writeQuoted(w, m.Name)
+	writeSpace(w)
+	writeString(w, m.Type)
+	if m.Charset != nil && (!m.Charset.IsBinary() || name == "enum" || name == "set") {
+		writeShowCharset(w, m.Charset, t, v)
+	}
+	if !m.Nullable {
+		writeString(w, " NOT NULL")
+	} else if name == "timestamp" {
+		writeString(w, " NULL")
+	}
+	writeShowDefault(w, m, name, v)
+	if m.OnUpdate != emptyString {
+		writeString(w, " ON UPDATE ")
+		writeString(w, m.OnUpdate)
+	}
+	if m.AutoIncrement {
+		writeString(w, " AUTO_INCREMENT")
+	}
+	switch {
+	case !m.Invisible:
+	case v.IsMariaDB():
+		writeString(w, " INVISIBLE")
+	default:
+		writeString(w, " /*!80023 INVISIBLE */")
+	}
+	if m.Comment != emptyString {
+		writeString(w, " COMMENT ")
+		writeString(w, quoteShow(m.Comment, v))
+	}
+}
+
+func writeShowCharset(w io.Writer, cs, t *Charset, v Version) {
+	if t == nil || t.Id != cs.Id {
+		writeString(w, " CHARACTER SET ")
+		writeString(w, charsetName(cs.Name, v))
+	}
+	if !cs.IsPrimary(v.Id) || (isMySQL80Default(cs, v) && (t == nil || t.Id != cs.Id)) {
+		writeString(w, " COLLATE ")
+		writeString(w, charsetName(cs.Collate, v))
+	}
+}
+
+// writeShowDefault writes the DEFAULT clause of a column whose type is
+// name. MySQL prints no default for BLOB and TEXT columns.
+func writeShowDefault(w io.Writer, m Column, name string, v Version) {
+	if m.AutoIncrement || (!m.HasDefault && !m.Nullable) {
+		return
+	}
+	if (strings.HasSuffix(name, "blob") || strings.HasSuffix(name, "text")) && !v.IsMariaDB() {
+		return
+	}
+	switch {
+	case !m.HasDefault:
+		writeString(w, " DEFAULT NULL")
+	case m.DefaultExpr:
+		writeString(w, " DEFAULT ")
+		writeString(w, m.Default)
+	case name == "bit":
+		writeString(w, " DEFAULT b'")
+		writeString(w, m.Default)
+		writeString(w, "'")
+	case numericTypes[name] && v.IsMariaDB():
+		writeString(w, " DEFAULT ")
+		writeString(w, m.Default)
+	default:
+		writeString(w, " DEFAULT ")
+		writeString(w, quoteShow(m.Default, v))
+	}
+}
+
+func (c *column) writeCurrentTimeStamp(w io.Writer, v Version) {
+	fsp := c.fractionDigits(dateTimeWidth)
+	if v.IsMariaDB() {
+		writeString(w, "current_timestamp(")
+		if fsp > 0 {
+			writeNumber(w, fsp)
+		}
+		writeCloseParen(w)
+		return
+	}
+	writeString(w, "CURRENT_TIMESTAMP")
+	if fsp > 0 {
+		writeParened(w, fsp)
+	}
+}
+
+func (c *column) writeShowSign(w io.Writer) {
+	if c.isUnsigned() {
+		writeString(w, " unsigned")
+	}
+	if c.isZeroFill() {
+		writeString(w, " zerofill")
+	}
+}
+
+func (c *column) writeShowInt(w io.Writer, s string, v Version) {
+	writeString(w, s)
+	if !v.hidesDisplayWidth() || c.isZeroFill() || (c.fieldType == tinyFieldType && c.fieldLength == 1) {
+		c.writeSize(w)
+	}
+	c.writeShowSign(w)
+}
+
+func (c *column) writeShowReal(w io.Writer, s string) {
+	writeString(w, s)
+	if dec := c.decimals(); dec != notFixedDec {
+		writeOpenParen(w)
+		writeNumber(w, int(c.fieldLength))
+		writeComma(w)
+		writeNumber(w, dec)
+		writeCloseParen(w)
+	}
+	c.writeShowSign(w)
+}
+
+func (c *column) writeShowTime(w io.Writer, s string, width int) {
+	writeString(w, s)
+	if fsp := c.fractionDigits(width); fsp > 0 {
+		writeParened(w, fsp)
+	}
+}
+
+func (c *column) writeShowString(w io.Writer, s, b string) {
+	if c.charsetNum() == binaryCharset {
+		writeString(w, b)
+		c.writeSize(w)
+		return
+	}
+	writeString(w, s)
+	writeParened(w, int(c.fieldLength)/c.maxLen())
+}
+
+func (c *column) writeShowBlob(w io.Writer, s string) {
+	writeString(w, s)
+	if c.charsetNum() == binaryCharset {
+		writeString(w, "blob")
+	} else {
+		writeString(w, "text")
+	}
+}
+
+func (c *column) writeShowIntervals(w io.Writer, s string, v Version) {
+	writeString(w, s)
+	writeOpenParen(w)
+	for i, value := range c.intervals {
+		if i > 0 {
+			writeComma(w)
+		}
+		// a value that cannot be decoded keeps its bytes as a hex literal
+		if text, err := c.stringValue([]byte(value)); err == nil {
+			writeString(w, quoteShow(text, v))
+		} else {
+			writeString(w, "0x"+hex.EncodeToString([]byte(value)))
+		}
+	}
+	writeCloseParen(w)
+}
+
+func (c *column) writeShowType(w io.Writer, v Version) {
+	switch c.fieldType {
+	case tinyFieldType:
+		c.writeShowInt(w, "tinyint", v)
+	case shortFieldType:
+		c.writeShowInt(w, "smallint", v)
+	case int24FieldType:
+		c.writeShowInt(w, "mediumint", v)
+	case longFieldType:
+		c.writeShowInt(w, "int", v)
+	case longLongFieldType:
+		c.writeShowInt(w, "bigint", v)
+	case floatFieldType:
+		c.writeShowReal(w, "float")
+	case doubleFieldType:
+		c.writeShowReal(w, "double")
+	case decimalFieldType, newDecimalFieldType:
+		writeString(w, "decimal")
+		writeOpenParen(w)
+		writeNumber(w, c.precision())
+		writeComma(w)
+		writeNumber(w, c.decimals())
+		writeCloseParen(w)
+		c.writeShowSign(w)
+	case yearFieldType:
+		writeString(w, "year")
+		if !v.hidesDisplayWidth() {
+			c.writeSize(w)
+		}
+	case dateFieldType, newDateFieldType:
+		writeString(w, "date")
+	case timeFieldType, time2FieldType:
+		c.writeShowTime(w, "time", timeWidth)
+	case dateTimeFieldType, dateTime2FieldType:
+		c.writeShowTime(w, "datetime", dateTimeWidth)
+	case timeStampFieldType, timeStamp2FieldType:
+		c.writeShowTime(w, "timestamp", dateTimeWidth)
+	case bitFieldType:
+		writeString(w, "bit")
+		c.writeSize(w)
+	case varCharFieldType, varStringFieldType:
+		c.writeShowString(w, "varchar", "varbinary")
+	case stringFieldType:
+		c.writeShowString(w, "char", "binary")
+	case enumFieldType:
+		c.writeShowIntervals(w, "enum", v)
+	case setFieldType:
+		c.writeShowIntervals(w, "set", v)
+	case tinyBlobFieldType:
+		c.writeShowBlob(w, "tiny")
+	case mediumBlobFieldType:
+		c.writeShowBlob(w, "medium")
+	case longBlobFieldType:
+		c.writeShowBlob(w, "long")
+	case blobFieldType:
+		c.writeShowBlob(w, emptyString)
+	case geometryFieldType:
+		n := c.charsetNum()
+		switch {
+		case n == geometryCollectionGeomType && v.IsMySQL80():
+			writeString(w, "geomcollection")
+		case n < len(geomTypes):
+			writeString(w, geomTypes[n])
+		default:
+			writeString(w, geomTypes[geometryGeomType])
+		}
+	case jsonFieldType:
+		writeString(w, "json")
+	default:
+		writeString(w, "<UNKNOWN_TYPE>")
+	}
+}
+
+// writeKey writes an index definition as SHOW CREATE TABLE of the
+// release v prints it.
+func writeKey(w io.Writer, m Key, v Version) {
+	writeString(w, string(m.Type))
+	if m.Type != PrimaryKey {
+		writeSpace(w)
+		writeQuoted(w, m.Name)
+	}
+	writeString(w, " (")
+	for i, p := range m.Parts {
+		if i > 0 {
+			writeComma(w)
+		}
+		if p.Expr != emptyString {
+			writeString(w, "(")
+			writeString(w, p.Expr)
+			writeCloseParen(w)
+		} else {
+			writeQuoted(w, p.Column)
+		}
+		if p.Length > 0 {
+			writeParened(w, p.Length)
+		}
+		if p.Desc && v.hasDescKeys() {
+			writeString(w, " DESC")
+		}
+	}
+	writeCloseParen(w)
+	if m.Algorithm != emptyString {
+		writeString(w, " USING ")
+		writeString(w, m.Algorithm)
+	}
+	if m.BlockSize > 0 {
+		writeString(w, " KEY_BLOCK_SIZE=")
+		writeNumber(w, m.BlockSize)
+	}
+	if m.Parser != emptyString {
+		writeString(w, " /*!50100 WITH PARSER ")
+		writeQuoted(w, m.Parser)
+		writeString(w, " */")
+	}
+	if m.Comment != emptyString {
+		writeString(w, " COMMENT ")
+		writeString(w, quoteShow(m.Comment, v))
+	}
+}
@@ -0,0 +1,214 @@
+package frm
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// names of the bits of the sql_mode variable
+	sqlModes = []string{
+		"REAL_AS_FLOAT",
+		"PIPES_AS_CONCAT",
+		"ANSI_QUOTES",
+		"IGNORE_SPACE",
+		"NOT_USED",
+		"ONLY_FULL_GROUP_BY",
+		"NO_UNSIGNED_SUBTRACTION",
+		"NO_DIR_IN_CREATE",
+		"POSTGRESQL",
+		"ORACLE",
+		"MSSQL",
+		"DB2",
+		"MAXDB",
+		"NO_KEY_OPTIONS",
+		"NO_TABLE_OPTIONS",
+		"NO_FIELD_OPTIONS",
+		"MYSQL323",
+		"MYSQL40",
+		"ANSI",
+		"NO_AUTO_VALUE_ON_ZERO",
+		"NO_BACKSLASH_ESCAPES",
+		"STRICT_TRANS_TABLES",
+		"STRICT_ALL_TABLES",
+		"NO_ZERO_IN_DATE",
+		"NO_ZERO_DATE",
+		"ALLOW_INVALID_DATES",
+		"ERROR_FOR_DIVISION_BY_ZERO",
+		"TRADITIONAL",
+		"NO_AUTO_CREATE_USER",
+		"HIGH_NOT_PRECEDENCE",
+		"NO_ENGINE_SUBSTITUTION",
+		"PAD_CHAR_TO_FULL_LENGTH",
+	}
+)
+
+const (
+	triggerKeyword = "TRIGGER"
+)
+
+// Trigger is a trigger read from the .TRG file of its table. Order is
+// the position among the triggers of the same timing and event, Created
+// is zero when the file does not keep it.
+type Trigger struct {
+	Name              string
+	Table             string
+	Timing            string
+	Event             string
+	Order             int
+	Statement         string
+	Definition        string
+	SQLMode           string
+	Definer           string
+	ClientCharset     string
+	Collation         string
+	DatabaseCollation string
+	Created           time.Time
+}
+
+// ReadTriggers reads the triggers of a table from its .TRG file.
+func ReadTriggers(path string) ([]Trigger, error) {
+	options, err := readParseFile(path, triggersParseType)
+	if err != nil {
+		return nil, err
+	}
+	definitions := splitValues(options["triggers"])
+	modes := splitValues(options["sql_modes"])
+	definers := splitValues(options["definers"])
+	clientCharsets := splitValues(options["client_cs_names"])
+	collations := splitValues(options["connection_cl_names"])
+	dbCollations := splitValues(options["db_cl_names"])
+	created := splitValues(options["created"])
+	orders := make(map[string]int)
+	triggers := make([]Trigger, 0, len(definitions))
+	for i, s := range definitions {
+		t := parseCreateTrigger(s)
+		if t.Name == emptyString {
+			return nil, WrongParseFileErr
+		}
+		t.Definition = s
+		orders[t.Timing+" "+t.Event]++
+		t.Order = orders[t.Timing+" "+t.Event]
+		t.SQLMode = sqlModeNames(listValue(modes, i))
+		t.Definer = listValue(definers, i)
+		t.ClientCharset = listValue(clientCharsets, i)
+		t.Collation = listValue(collations, i)
+		t.DatabaseCollation = listValue(dbCollations, i)
+		if n, err := strconv.ParseInt(listValue(created, i), 10, 64); err == nil && n > 0 {
+			// hundredths of a second
+			t.Created = time.Unix(n/100, n%100*int64(10*time.Millisecond)).UTC()
+		}
+		triggers = append(triggers, t)
+	}
+	return triggers, nil
+}
+
+func listValue(values []string, i int) string {
+	if i < len(values) {
+		return values[i]
+	}
+	return emptyString
+}
+
+// sqlModeNames returns the names of the bits of a sql_mode value.
+func sqlModeNames(s string) string {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return emptyString
+	}
+	names := make([]string, 0)
+	for i, name := range sqlModes {
+		if (n & (1 << uint(i))) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// nextWord returns the word of s at i and the position after it. Quoted
+// identifiers are unquoted, a word ends at a space or, outside quotes,
+// at a dot.
+func nextWord(s string, i int) (string, int) {
+	for i < len(s) && isSpace(s[i]) {
+		i++
+	}
+	if i < len(s) && s[i] == '`' {
+		b := &strings.Builder{}
+		for i++; i < len(s); i++ {
+			if s[i] == '`' {
+				if i+1 < len(s) && s[i+1] == '`' {
+					i++
+				} else {
+					return b.String(), i + 1
+				}
+			}
+			b.WriteByte(s[i])
+		}
+		return b.String(), i
+	}
+	j := i
+	quoted := false
+	for j < len(s) && (quoted || (!isSpace(s[j]) && s[j] != '.')) {
+		if s[j] == '`' {
+			quoted = !quoted
+		}
+		j++
+	}
+	return s[i:j], j
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// nextName returns a name that may be qualified with its database and
+// the position after it.
+func nextName(s string, i int) (string, int) {
+	name, i := nextWord(s, i)
+	for i < len(s) && s[i] == '.' {
+		name, i = nextWord(s, i+1)
+	}
+	return name, i
+}
+
+// parseCreateTrigger reads the name, timing, event, table and statement
+// of a CREATE TRIGGER statement. The name is empty when s is not one.
+func parseCreateTrigger(s string) Trigger {
+	t := Trigger{}
+	i := 0
+	for {
+		w, j := nextWord(s, i)
+		if w == emptyString {
+			return t
+		}
+		i = j
+		if strings.EqualFold(w, triggerKeyword) {
+			break
+		}
+	}
+	name, i := nextName(s, i)
+	timing, i := nextWord(s, i)
+	event, i := nextWord(s, i)
+	on, i := nextWord(s, i)
+	if !strings.EqualFold(on, "ON") {
+		return t
+	}
+	table, i := nextName(s, i)
+	for _, keyword := range []string{"FOR", "EACH", "ROW"} {
+		w, j := nextWord(s, i)
+		if !strings.EqualFold(w, keyword) {
+			return t
+		}
+		i = j
+	}
+	if w, j := nextWord(s, i); strings.EqualFold(w, "FOLLOWS") || strings.EqualFold(w, "PRECEDES") {
+		_, i = nextName(s, j)
+	}
+	t.Name = name
+	t.Timing = strings.ToUpper(timing)
+	t.Event = strings.ToUpper(event)
+	t.Table = table
+	t.Statement = strings.TrimSpace(s[i:])
+	return t
+}
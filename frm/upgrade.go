@@ -0,0 +1,410 @@
+package frm
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	utf8CharsetName   = "utf8"
+	utf8mb4Charset    = "utf8mb4"
+	ndbEngine         = "ndbcluster"
+	dynamicRowType    = 2
+	compressedRowType = 3
+	compactRowType    = 5
+	smallPrefixLength = 767
+	largePrefixLength = 3072
+	databaseObject    = "database"
+)
+
+var (
+	// databases the server upgrades by itself
+	systemDatabases = map[string]bool{
+		"mysql":              true,
+		"information_schema": true,
+		"performance_schema": true,
+		"sys":                true,
+	}
+	// words reserved since the release id, the ones a table of an older
+	// release may use unquoted
+	reservedWords = map[string]uint32{
+		"GENERAL":                 50500,
+		"IGNORE_SERVER_IDS":       50500,
+		"MASTER_HEARTBEAT_PERIOD": 50500,
+		"MAXVALUE":                50500,
+		"RESIGNAL":                50500,
+		"SIGNAL":                  50500,
+		"SLOW":                    50500,
+		"PARTITION":               50600,
+		"GET":                     50604,
+		"IO_AFTER_GTIDS":          50605,
+		"IO_BEFORE_GTIDS":         50605,
+		"MASTER_BIND":             50606,
+		"GENERATED":               50706,
+		"OPTIMIZER_COSTS":         50706,
+		"STORED":                  50706,
+		"VIRTUAL":                 50706,
+		"EXCEPT":                  80000,
+		"CUBE":                    80001,
+		"FUNCTION":                80001,
+		"GROUPING":                80001,
+		"OF":                      80001,
+		"RECURSIVE":               80001,
+		"CUME_DIST":               80002,
+		"DENSE_RANK":              80002,
+		"FIRST_VALUE":             80002,
+		"GROUPS":                  80002,
+		"LAG":                     80002,
+		"LAST_VALUE":              80002,
+		"LEAD":                    80002,
+		"NTH_VALUE":               80002,
+		"NTILE":                   80002,
+		"OVER":                    80002,
+		"PERCENT_RANK":            80002,
+		"RANK":                    80002,
+		"ROW":                     80002,
+		"ROWS":                    80002,
+		"ROW_NUMBER":              80002,
+		"WINDOW":                  80002,
+		"SYSTEM":                  80003,
+		"EMPTY":                   80004,
+		"JSON_TABLE":              80004,
+		"LATERAL":                 80014,
+		"ARRAY":                   80017,
+		"MEMBER":                  80017,
+		"INTERSECT":               80031,
+	}
+)
+
+// Problem is a part of a table definition that stops an upgrade or
+// that the upgraded server treats differently.
+type Problem struct {
+	Object  string
+	Message string
+}
+
+func (p Problem) String() string {
+	return p.Object + ": " + p.Message
+}
+
+// UpgradeReport lists the problems of a table for a release and the
+// ALTER TABLE statement that fixes them before the upgrade. Alter is
+// empty when nothing can be fixed that way.
+type UpgradeReport struct {
+	Database string
+	Table    string
+	Problems []Problem
+	Alter    string
+}
+
+type upgradeChecker struct {
+	f        *Frm
+	v        Version
+	report   *UpgradeReport
+	modified map[int]*column
+	options  []string
+}
+
+func (uc *upgradeChecker) problem(object, message string) {
+	uc.report.Problems = append(uc.report.Problems, Problem{Object: object, Message: message})
+}
+
+// modify returns the copy of the column that the ALTER TABLE statement
+// redefines.
+func (uc *upgradeChecker) modify(i int) *column {
+	c, ok := uc.modified[i]
+	if !ok {
+		cc := uc.f.columns[i]
+		c = &cc
+		uc.modified[i] = c
+	}
+	return c
+}
+
+func (uc *upgradeChecker) option(s string) {
+	for _, o := range uc.options {
+		if o == s {
+			return
+		}
+	}
+	uc.options = append(uc.options, s)
+}
+
+// isReserved reports whether v reserves the word.
+func isReserved(s string, v Version) bool {
+	since, ok := reservedWords[strings.ToUpper(s)]
+	return ok && !v.IsMariaDB() && v.Id >= since
+}
+
+// isZeroDate reports whether a default value has a zero date, which the
+// NO_ZERO_DATE and NO_ZERO_IN_DATE modes reject.
+func isZeroDate(s string) bool {
+	return len(s) >= 10 && (s[0:4] == "0000" || s[5:7] == "00" || s[8:10] == "00")
+}
+
+func (c *column) isDate() bool {
+	switch c.fieldType {
+	case dateFieldType,
+		newDateFieldType,
+		dateTimeFieldType,
+		dateTime2FieldType,
+		timeStampFieldType,
+		timeStamp2FieldType:
+		return true
+	}
+	return false
+}
+
+// hasLargePrefix reports whether DYNAMIC and COMPRESSED tables of v
+// allow index keys of 3072 bytes by default.
+func (v Version) hasLargePrefix() bool {
+	return v.Id >= 50707 && (!v.IsMariaDB() || v.Id >= 100202)
+}
+
+// maxPrefixLength returns the longest key part in bytes an InnoDB table
+// of the row format allows on v.
+func maxPrefixLength(rowType uint8, v Version) int {
+	switch rowType {
+	case dynamicRowType, compressedRowType:
+	case 0:
+		if v.Id < 50709 || (v.IsMariaDB() && v.Id < 100202) {
+			return smallPrefixLength
+		}
+	default:
+		return smallPrefixLength
+	}
+	if v.hasLargePrefix() {
+		return largePrefixLength
+	}
+	return smallPrefixLength
+}
+
+// utf8mb4Collation returns the utf8mb4 collation matching a utf8 one.
+func utf8mb4Collation(cs *Charset, v Version) *Charset {
+	if to, err := CharsetByName(utf8mb4Charset + strings.TrimPrefix(cs.Collate, utf8CharsetName)); err == nil && isCollationAvailable(to, v) {
+		return to
+	}
+	to, _ := DefaultCharset(utf8mb4Charset, v.Id)
+	return to
+}
+
+// CheckUpgrade reports what stops an upgrade of the table to the release
+// v and suggests an ALTER TABLE statement to run before the upgrade.
+func (f *Frm) CheckUpgrade(database, table string, v Version) *UpgradeReport {
+	uc := &upgradeChecker{
+		f:        f,
+		v:        v,
+		report:   &UpgradeReport{Database: database, Table: table},
+		modified: make(map[int]*column),
+	}
+	uc.checkTable()
+	for i := range f.columns {
+		uc.checkColumn(i)
+	}
+	for i := range f.keys {
+		uc.checkKey(&f.keys[i])
+	}
+	uc.writeAlter()
+	return uc.report
+}
+
+func (uc *upgradeChecker) checkTable() {
+	f, v := uc.f, uc.v
+	source := f.Version()
+	if isReserved(uc.report.Table, v) && !isReserved(uc.report.Table, source) {
+		uc.problem(tableObject, "name is a reserved word in "+v.String()+" and has to be quoted")
+	}
+	if cs := f.tableCharset(); cs != nil && cs.Name == utf8CharsetName && v.IsMySQL80() {
+		to := utf8mb4Collation(cs, v)
+		uc.problem(tableObject, "default character set utf8 is deprecated, use utf8mb4")
+		uc.option("DEFAULT CHARSET=" + to.Name + " COLLATE=" + to.Collate)
+	}
+	engine := f.Engine()
+	if f.partitionInfo != emptyString && v.IsMySQL80() && engine != innoDBEngine && engine != ndbEngine {
+		uc.problem(tableObject, "partitioning of "+engine+" tables is not supported")
+		uc.option("ENGINE=" + innoDBEngine)
+	}
+}
+
+func (uc *upgradeChecker) checkColumn(i int) {
+	f, v := uc.f, uc.v
+	c := &f.columns[i]
+	object := columnObject(c.name)
+	if isReserved(c.name, v) && !isReserved(c.name, f.Version()) {
+		uc.problem(object, "name is a reserved word in "+v.String()+" and has to be quoted")
+	}
+	switch c.fieldType {
+	case decimalFieldType:
+		uc.problem(object, "DECIMAL has the storage format of MySQL 5.0.2 and older")
+		uc.modify(i).fieldType = newDecimalFieldType
+	case varStringFieldType:
+		uc.problem(object, "VARCHAR has the storage format of MySQL 5.0.2 and older")
+		uc.modify(i).fieldType = varCharFieldType
+	case timeFieldType, dateTimeFieldType, timeStampFieldType:
+		if v.Id >= 50604 {
+			uc.problem(object, "temporal type has the storage format of MySQL 5.6.3 and older")
+			uc.modify(i).fieldType = newTemporalTypes[c.fieldType]
+		}
+	}
+	if c.isDate() && c.hasDefault && isZeroDate(c.defaultValue) && !v.IsMariaDB() && v.Id >= 50708 {
+		uc.problem(object, "zero date default "+QuoteString(c.defaultValue)+" is rejected by the default SQL mode")
+		uc.modify(i).hasDefault = false
+	}
+	if c.hasCharset() && v.IsMySQL80() {
+		if cs := c.charsetA(); cs.Name == utf8CharsetName {
+			to := utf8mb4Collation(cs, v)
+			uc.problem(object, "character set utf8 is deprecated, use utf8mb4")
+			cc := uc.modify(i)
+			cc.charset = uint8(to.Id)
+			cc.charsetLow = uint8(to.Id >> 8)
+		}
+	}
+}
+
+func (uc *upgradeChecker) checkKey(k *key) {
+	f, v := uc.f, uc.v
+	if f.Engine() != innoDBEngine || (k.flags&(fullTextKeyFlag|spatialKeyFlag)) != 0 {
+		return
+	}
+	limit := maxPrefixLength(f.rowType, v)
+	for i := range k.parts {
+		p := &k.parts[i]
+		if p.expr != emptyString {
+			continue
+		}
+		n := p.fieldNumA()
+		length := int(p.length)
+		if c, ok := uc.modified[n]; ok && c.hasCharset() {
+			length = length / f.columns[n].maxLen() * c.maxLen()
+		}
+		if length <= limit {
+			continue
+		}
+		object := keyObject(k.name)
+		message := "part " + QuoteIdent(f.columns[n].name) + " of " + strconv.Itoa(length) + " bytes is longer than " + strconv.Itoa(limit)
+		if length <= largePrefixLength && v.hasLargePrefix() {
+			uc.problem(object, message+", the table needs ROW_FORMAT=DYNAMIC")
+			uc.option("ROW_FORMAT=DYNAMIC")
+		} else {
+			uc.problem(object, message+", the prefix has to be shortened")
+		}
+	}
+}
+
+func (uc *upgradeChecker) writeAlter() {
+	if len(uc.modified) == 0 && len(uc.options) == 0 {
+		return
+	}
+	indexes := make([]int, 0, len(uc.modified))
+	for i := range uc.modified {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	source := uc.f.Version()
+	b := &bytes.Buffer{}
+	writeString(b, "ALTER TABLE ")
+	if uc.report.Database != emptyString {
+		writeQuoted(b, uc.report.Database)
+		writeString(b, ".")
+	}
+	writeQuoted(b, uc.report.Table)
+	for n, i := range indexes {
+		if n > 0 {
+			writeString(b, ",")
+		}
+		writeString(b, " MODIFY ")
+		// no table character set, so that every column names its own
+		writeColumn(b, uc.modified[i].modelFor(source), nil, source)
+	}
+	for n, o := range uc.options {
+		if n > 0 || len(indexes) > 0 {
+			writeString(b, ",")
+		}
+		writeSpace(b)
+		writeString(b, o)
+	}
+	uc.report.Alter = b.String()
+}
+
+// CheckUpgradeDataDir checks the tables of every database in a data
+// directory and returns the reports with problems. The system databases
+// and views are skipped.
+func CheckUpgradeDataDir(dataDir string, v Version) ([]*UpgradeReport, error) {
+	dirs, err := ioutil.ReadDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*UpgradeReport, 0)
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		database, err := DecodeFilename(dir.Name())
+		if err != nil {
+			reports = append(reports, &UpgradeReport{Database: dir.Name(), Problems: []Problem{
+				{Object: databaseObject, Message: "not checked, the directory name cannot be decoded: " + err.Error()},
+			}})
+			continue
+		}
+		if systemDatabases[database] {
+			continue
+		}
+		list, err := CheckUpgradeDir(filepath.Join(dataDir, dir.Name()), database, v)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, list...)
+	}
+	return reports, nil
+}
+
+// CheckUpgradeDir checks the tables of the database directory dir and
+// returns the reports with problems. A table that cannot be read is
+// reported as a problem.
+func CheckUpgradeDir(dir, database string, v Version) ([]*UpgradeReport, error) {
+	reports := make([]*UpgradeReport, 0)
+	err := readDir(dir, func(table string, f *Frm) {
+		if r := f.CheckUpgrade(database, table, v); len(r.Problems) > 0 {
+			reports = append(reports, r)
+		}
+	}, func(table string, err error) {
+		reports = append(reports, &UpgradeReport{Database: database, Table: table, Problems: []Problem{
+			{Object: tableObject, Message: "not checked, the .frm file cannot be read: " + err.Error()},
+		}})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// WriteUpgradeReport writes the reports as an SQL script: the problems
+// as comments followed by the suggested statements.
+func WriteUpgradeReport(w io.Writer, reports []*UpgradeReport) {
+	for _, r := range reports {
+		name := QuoteIdent(r.Table)
+		switch {
+		case r.Table == emptyString:
+			name = QuoteIdent(r.Database)
+		case r.Database != emptyString:
+			name = QuoteIdent(r.Database) + "." + name
+		}
+		for _, p := range r.Problems {
+			writeString(w, "-- ")
+			writeString(w, name)
+			writeSpace(w)
+			writeString(w, p.String())
+			writeString(w, "\n")
+		}
+		if r.Alter != emptyString {
+			writeString(w, r.Alter)
+			writeString(w, ";\n")
+		}
+		writeString(w, "\n")
+	}
+}
@@ -0,0 +1,103 @@
+package frm
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	WrongVersionErr = errors.New("Wrong server version.")
+)
+
+const (
+	mariaDBVersionId = 100000
+	mariaDBSuffix    = "-MariaDB"
+)
+
+// Version is a server release. Id is encoded like MYSQL_VERSION_ID, 50744
+// for 5.7.44, MariaDB releases are 100000 and above.
+type Version struct {
+	Id uint32
+}
+
+var (
+	MySQL55    = Version{Id: 50562}
+	MySQL56    = Version{Id: 50651}
+	MySQL57    = Version{Id: 50744}
+	MySQL80    = Version{Id: 80040}
+	MariaDB106 = Version{Id: 100620}
+)
+
+// ParseVersion parses a version string like "5.7.44-log" or
+// "10.6.20-MariaDB".
+func ParseVersion(s string) (Version, error) {
+	if i := strings.IndexAny(s, "-+ "); i >= 0 {
+		s = s[:i]
+	}
+	nums := strings.Split(s, ".")
+	if len(nums) != 3 {
+		return Version{}, WrongVersionErr
+	}
+	id := 0
+	for _, n := range nums {
+		i, err := strconv.Atoi(n)
+		if err != nil || i < 0 || i > 99 {
+			return Version{}, WrongVersionErr
+		}
+		id = id*100 + i
+	}
+	return Version{Id: uint32(id)}, nil
+}
+
+// IsMariaDB reports whether the release is a MariaDB one.
+func (v Version) IsMariaDB() bool {
+	return v.Id >= mariaDBVersionId
+}
+
+// IsMySQL80 reports whether the release is MySQL 8.0 or later.
+func (v Version) IsMySQL80() bool {
+	return v.Id >= mySQL80VersionId && !v.IsMariaDB()
+}
+
+func (v Version) String() string {
+	s := strconv.Itoa(int(v.Id/10000)) + "." + strconv.Itoa(int(v.Id/100%100)) + "." + strconv.Itoa(int(v.Id%100))
+	if v.IsMariaDB() {
+		s += mariaDBSuffix
+	}
+	return s
+}
+
+// Version returns the release of the server that wrote the file.
+func (f *Frm) Version() Version {
+	return Version{Id: f.mySQLVersionId}
+}
+
+// hidesDisplayWidth reports whether integer display widths are omitted,
+// as MySQL does since 8.0.19.
+func (v Version) hidesDisplayWidth() bool {
+	return v.IsMySQL80() && v.Id >= 80019
+}
+
+// hasUtf8mb3 reports whether utf8 is printed as utf8mb3, as MySQL does
+// since 8.0.30 and MariaDB since 10.6.1.
+func (v Version) hasUtf8mb3() bool {
+	return (v.IsMySQL80() && v.Id >= 80030) || (v.IsMariaDB() && v.Id >= 100601)
+}
+
+// hasInvisibleColumns reports whether columns can be INVISIBLE, as they
+// can since MySQL 8.0.23 and MariaDB 10.3.3.
+func (v Version) hasInvisibleColumns() bool {
+	return (v.IsMySQL80() && v.Id >= 80023) || (v.IsMariaDB() && v.Id >= 100303)
+}
+
+// hasFunctionalKeys reports whether key parts can be expressions, as
+// they can since MySQL 8.0.13.
+func (v Version) hasFunctionalKeys() bool {
+	return v.IsMySQL80() && v.Id >= 80013
+}
+
+// hasDescKeys reports whether descending key parts are kept.
+func (v Version) hasDescKeys() bool {
+	return v.IsMySQL80() || (v.IsMariaDB() && v.Id >= 100800)
+}
@@ -0,0 +1,64 @@
+package frm
+
+import (
+	"strconv"
+)
+
+var (
+	viewAlgorithms   = []string{"UNDEFINED", "TEMPTABLE", "MERGE"}
+	viewCheckOptions = []string{"NONE", "LOCAL", "CASCADED"}
+)
+
+const (
+	invokerViewSuid = 0
+)
+
+// View is a view read from its .frm file. Query is the SELECT as the
+// server rewrote it and Body the one that was written, when the file
+// keeps it. Definer is written like "user@host".
+type View struct {
+	Query         string
+	Body          string
+	Algorithm     string
+	Definer       string
+	Security      string
+	CheckOption   string
+	Updatable     bool
+	ClientCharset string
+	Collation     string
+}
+
+// NewView reads the .frm file of a view.
+func NewView(path string) (*View, error) {
+	options, err := readParseFile(path, viewParseType)
+	if err != nil {
+		return nil, err
+	}
+	v := &View{
+		Query:         unescapeValue(options["query"]),
+		Body:          unescapeValue(options["view_body_utf8"]),
+		Algorithm:     enumValue(viewAlgorithms, options["algorithm"]),
+		Definer:       unescapeValue(options["definer_user"]) + "@" + unescapeValue(options["definer_host"]),
+		Security:      "DEFINER",
+		CheckOption:   enumValue(viewCheckOptions, options["with_check_option"]),
+		Updatable:     options["updatable"] == "1",
+		ClientCharset: options["client_cs_name"],
+		Collation:     options["connection_cl_name"],
+	}
+	if v.Body == emptyString {
+		v.Body = unescapeValue(options["source"])
+	}
+	if n, err := strconv.Atoi(options["suid"]); err == nil && n == invokerViewSuid {
+		v.Security = "INVOKER"
+	}
+	return v, nil
+}
+
+// enumValue returns the name of a number, the first name when it is out
+// of range.
+func enumValue(names []string, s string) string {
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n < len(names) {
+		return names[n]
+	}
+	return names[0]
+}
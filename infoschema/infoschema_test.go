@@ -0,0 +1,89 @@
+package infoschema
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	dataDir = "data/"
+)
+
+func TestLoad(t *testing.T) {
+	s, err := Load(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(s.Schemata.Rows); n != 2 {
+		t.Fatalf("schemata: %d rows", n)
+	}
+	rs := s.Columns.Where("column_name", "user_id")
+	if len(rs.Rows) != 1 || rs.String(rs.Rows[0], "TABLE_NAME") != "Orders" {
+		t.Fatalf("user_id columns: %v", rs.Rows)
+	}
+	if k := rs.String(rs.Rows[0], "COLUMN_KEY"); k != "MUL" {
+		t.Fatalf("user_id key: %q", k)
+	}
+	// indexes on utf8 columns
+	utf8 := s.Columns.Like("CHARACTER_SET_NAME", "utf8%")
+	rs = s.Statistics.Filter(func(row Row) bool {
+		for _, c := range utf8.Rows {
+			if utf8.Value(c, "TABLE_SCHEMA") == s.Statistics.Value(row, "TABLE_SCHEMA") &&
+				utf8.Value(c, "TABLE_NAME") == s.Statistics.Value(row, "TABLE_NAME") &&
+				utf8.Value(c, "COLUMN_NAME") == s.Statistics.Value(row, "COLUMN_NAME") {
+				return true
+			}
+		}
+		return false
+	}).Select("TABLE_NAME", "INDEX_NAME", "SUB_PART")
+	if len(rs.Rows) != 1 || rs.String(rs.Rows[0], "INDEX_NAME") != "name" || rs.Value(rs.Rows[0], "SUB_PART") != int64(10) {
+		t.Fatalf("utf8 indexes: %v", rs.Rows)
+	}
+	rs = s.Tables.Where("TABLE_TYPE", "VIEW")
+	if len(rs.Rows) != 1 || len(s.Views.Rows) != 1 || s.Views.String(s.Views.Rows[0], "CHECK_OPTION") != "CASCADED" {
+		t.Fatalf("views: %v %v", rs.Rows, s.Views.Rows)
+	}
+	rs = s.Rowset("triggers").Where("ACTION_TIMING", "before")
+	if len(rs.Rows) != 1 || rs.String(rs.Rows[0], "ACTION_STATEMENT") != "SET NEW.status = 0" {
+		t.Fatalf("triggers: %v", rs.Rows)
+	}
+}
+
+func TestDump(t *testing.T) {
+	s, err := Load(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "infoschema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err = s.Dump(dir, CSV); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "TABLES.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "def,shop,v_orders,VIEW,\\N,\\N,\\N,VIEW\n") {
+		t.Fatalf("TABLES.csv:\n%s", data)
+	}
+	if err = s.Dump(dir, JSON); err != nil {
+		t.Fatal(err)
+	}
+	data, err = ioutil.ReadFile(filepath.Join(dir, "SCHEMATA.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &bytes.Buffer{}
+	s.Schemata.Where("SCHEMA_NAME", "legacy").WriteJSON(b)
+	want := `{"CATALOG_NAME": "def", "SCHEMA_NAME": "legacy", "DEFAULT_CHARACTER_SET_NAME": "latin1", "DEFAULT_COLLATION_NAME": "latin1_swedish_ci"}`
+	if !strings.Contains(b.String(), want) || !strings.Contains(string(data), want) {
+		t.Fatalf("SCHEMATA.json:\n%s", data)
+	}
+}
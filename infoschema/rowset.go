@@ -0,0 +1,207 @@
+package infoschema
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	csvNull = "\\N"
+)
+
+// Row is a row of a rowset. Values are strings, int64 or nil for NULL.
+type Row []interface{}
+
+// Rowset is an information_schema table: the names of its columns and
+// its rows.
+type Rowset struct {
+	Name    string
+	Columns []string
+	Rows    []Row
+}
+
+func newRowset(name string, columns ...string) *Rowset {
+	return &Rowset{Name: name, Columns: columns, Rows: make([]Row, 0)}
+}
+
+func (rs *Rowset) add(values ...interface{}) {
+	rs.Rows = append(rs.Rows, Row(values))
+}
+
+// Index returns the position of the named column, -1 when there is none.
+// Names are compared ignoring case, as the server does.
+func (rs *Rowset) Index(column string) int {
+	for i, name := range rs.Columns {
+		if strings.EqualFold(name, column) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Value returns the value of the named column of a row, nil when there
+// is no such column.
+func (rs *Rowset) Value(row Row, column string) interface{} {
+	if i := rs.Index(column); i >= 0 {
+		return row[i]
+	}
+	return nil
+}
+
+// String returns the value of the named column of a row as text, empty
+// for NULL.
+func (rs *Rowset) String(row Row, column string) string {
+	return format(rs.Value(row, column))
+}
+
+func format(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	}
+	return emptyString
+}
+
+// Filter returns a rowset of the rows fn accepts.
+func (rs *Rowset) Filter(fn func(row Row) bool) *Rowset {
+	res := newRowset(rs.Name, rs.Columns...)
+	for _, row := range rs.Rows {
+		if fn(row) {
+			res.Rows = append(res.Rows, row)
+		}
+	}
+	return res
+}
+
+// Where returns a rowset of the rows whose column equals value. Text is
+// compared ignoring case, like the utf8_general_ci collation of
+// information_schema.
+func (rs *Rowset) Where(column string, value interface{}) *Rowset {
+	i := rs.Index(column)
+	if n, ok := value.(int); ok {
+		value = int64(n)
+	}
+	return rs.Filter(func(row Row) bool {
+		if i < 0 {
+			return false
+		}
+		if s, ok := value.(string); ok {
+			t, ok := row[i].(string)
+			return ok && strings.EqualFold(s, t)
+		}
+		return row[i] == value
+	})
+}
+
+// Like returns a rowset of the rows whose column matches an SQL LIKE
+// pattern, ignoring case: % matches any text and _ one character.
+func (rs *Rowset) Like(column, pattern string) *Rowset {
+	i := rs.Index(column)
+	re := likePattern(pattern)
+	return rs.Filter(func(row Row) bool {
+		return i >= 0 && row[i] != nil && re.MatchString(format(row[i]))
+	})
+}
+
+func likePattern(pattern string) *regexp.Regexp {
+	b := &strings.Builder{}
+	b.WriteString("(?is)^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// Select returns a rowset of the named columns. Unknown columns are
+// NULL.
+func (rs *Rowset) Select(columns ...string) *Rowset {
+	res := newRowset(rs.Name, columns...)
+	index := make([]int, len(columns))
+	for i, column := range columns {
+		index[i] = rs.Index(column)
+	}
+	for _, row := range rs.Rows {
+		values := make(Row, len(columns))
+		for i, j := range index {
+			if j >= 0 {
+				values[i] = row[j]
+			}
+		}
+		res.Rows = append(res.Rows, values)
+	}
+	return res
+}
+
+// WriteCSV writes the rowset as CSV with a header line. NULL is written
+// as \N.
+func (rs *Rowset) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(rs.Columns); err != nil {
+		return err
+	}
+	record := make([]string, len(rs.Columns))
+	for _, row := range rs.Rows {
+		for i, v := range row {
+			record[i] = format(v)
+			if v == nil {
+				record[i] = csvNull
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the rowset as a JSON array of objects keyed by the
+// column names, in the order of the columns.
+func (rs *Rowset) WriteJSON(w io.Writer) error {
+	b := &bytes.Buffer{}
+	b.WriteString("[")
+	for i, row := range rs.Rows {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n  {")
+		for j, v := range row {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			name, _ := json.Marshal(rs.Columns[j])
+			value, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			b.Write(name)
+			b.WriteString(": ")
+			b.Write(value)
+		}
+		b.WriteString("}")
+	}
+	b.WriteString("\n]\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
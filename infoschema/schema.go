@@ -0,0 +1,352 @@
+// Package infoschema builds the information_schema tables of a MySQL
+// data directory from its files, without a running server.
+package infoschema
+
+import (
+	"github.com/freepk/mysql/frm"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Format is the format of a dump.
+type Format int
+
+const (
+	CSV Format = iota
+	JSON
+)
+
+const (
+	emptyString  = ""
+	catalog      = "def"
+	frmExt       = "frm"
+	trgExt       = "TRG"
+	baseTable    = "BASE TABLE"
+	systemView   = "VIEW"
+	createdTime  = "2006-01-02 15:04:05.00"
+	hashIndex    = "HASH"
+	bTreeIndex   = "BTREE"
+	memoryEngine = "MEMORY"
+)
+
+var (
+	// the databases of the server that are not directories
+	virtualDatabases = map[string]bool{
+		"information_schema": true,
+	}
+)
+
+// Schema holds the information_schema tables of a data directory.
+type Schema struct {
+	Schemata   *Rowset
+	Tables     *Rowset
+	Columns    *Rowset
+	Statistics *Rowset
+	Views      *Rowset
+	Triggers   *Rowset
+}
+
+func newSchema() *Schema {
+	return &Schema{
+		Schemata: newRowset("SCHEMATA",
+			"CATALOG_NAME", "SCHEMA_NAME", "DEFAULT_CHARACTER_SET_NAME", "DEFAULT_COLLATION_NAME"),
+		Tables: newRowset("TABLES",
+			"TABLE_CATALOG", "TABLE_SCHEMA", "TABLE_NAME", "TABLE_TYPE", "ENGINE", "ROW_FORMAT",
+			"TABLE_COLLATION", "TABLE_COMMENT"),
+		Columns: newRowset("COLUMNS",
+			"TABLE_CATALOG", "TABLE_SCHEMA", "TABLE_NAME", "COLUMN_NAME", "ORDINAL_POSITION",
+			"COLUMN_DEFAULT", "IS_NULLABLE", "DATA_TYPE", "CHARACTER_SET_NAME", "COLLATION_NAME",
+			"COLUMN_TYPE", "COLUMN_KEY", "EXTRA", "COLUMN_COMMENT"),
+		Statistics: newRowset("STATISTICS",
+			"TABLE_CATALOG", "TABLE_SCHEMA", "TABLE_NAME", "NON_UNIQUE", "INDEX_SCHEMA", "INDEX_NAME",
+			"SEQ_IN_INDEX", "COLUMN_NAME", "COLLATION", "SUB_PART", "NULLABLE", "INDEX_TYPE",
+			"INDEX_COMMENT"),
+		Views: newRowset("VIEWS",
+			"TABLE_CATALOG", "TABLE_SCHEMA", "TABLE_NAME", "VIEW_DEFINITION", "CHECK_OPTION",
+			"IS_UPDATABLE", "DEFINER", "SECURITY_TYPE", "CHARACTER_SET_CLIENT", "COLLATION_CONNECTION"),
+		Triggers: newRowset("TRIGGERS",
+			"TRIGGER_CATALOG", "TRIGGER_SCHEMA", "TRIGGER_NAME", "EVENT_MANIPULATION",
+			"EVENT_OBJECT_CATALOG", "EVENT_OBJECT_SCHEMA", "EVENT_OBJECT_TABLE", "ACTION_ORDER",
+			"ACTION_STATEMENT", "ACTION_ORIENTATION", "ACTION_TIMING", "CREATED", "SQL_MODE",
+			"DEFINER", "CHARACTER_SET_CLIENT", "COLLATION_CONNECTION", "DATABASE_COLLATION"),
+	}
+}
+
+// Rowsets returns the tables of the schema.
+func (s *Schema) Rowsets() []*Rowset {
+	return []*Rowset{s.Schemata, s.Tables, s.Columns, s.Statistics, s.Views, s.Triggers}
+}
+
+// Rowset returns the table of the given name, nil when there is none.
+func (s *Schema) Rowset(name string) *Rowset {
+	for _, rs := range s.Rowsets() {
+		if strings.EqualFold(rs.Name, name) {
+			return rs
+		}
+	}
+	return nil
+}
+
+// Load reads every database directory of a data directory. Temporary
+// tables are skipped.
+func Load(dataDir string) (*Schema, error) {
+	dirs, err := ioutil.ReadDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	s := newSchema()
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		database, err := frm.DecodeFilename(dir.Name())
+		if err != nil || virtualDatabases[database] {
+			continue
+		}
+		if err = s.loadDatabase(filepath.Join(dataDir, dir.Name()), database); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *Schema) loadDatabase(dir, database string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if cs, err := frm.ReadDbOpt(dir); err == nil {
+		s.Schemata.add(catalog, database, cs.Name, cs.Collate)
+	} else {
+		s.Schemata.add(catalog, database, nil, nil)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name() < files[j].Name() })
+	for _, file := range files {
+		tf, err := frm.ParseTableFile(file.Name())
+		if err != nil || tf.Temp || tf.Partition != emptyString {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		switch tf.Ext {
+		case frmExt:
+			err = s.loadTable(path, database, tf.Table)
+		case trgExt:
+			err = s.loadTriggers(path, database)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schema) loadTable(path, database, table string) error {
+	f, err := frm.NewFrm(path)
+	if err == frm.WrongFRMFileErr {
+		return s.loadView(path, database, table)
+	}
+	if err != nil {
+		return err
+	}
+	collation := interface{}(nil)
+	if cs := f.Charset(); cs != nil {
+		collation = cs.Collate
+	}
+	s.Tables.add(catalog, database, table, baseTable, f.Engine(), nullable(f.RowFormat()), collation, f.Comment())
+	keys := f.Keys()
+	columnKeys := columnKeys(f.Columns(), keys)
+	for i, c := range f.Columns() {
+		def := interface{}(nil)
+		if c.HasDefault {
+			def = c.Default
+		}
+		charset, collate := interface{}(nil), interface{}(nil)
+		if c.Charset != nil {
+			charset, collate = c.Charset.Name, c.Charset.Collate
+		}
+		extra := emptyString
+		if c.AutoIncrement {
+			extra = "auto_increment"
+		}
+		s.Columns.add(catalog, database, table, c.Name, int64(i+1), def, yesNo(c.Nullable),
+			dataType(c.Type), charset, collate, c.Type, columnKeys[c.Name], extra, c.Comment)
+	}
+	nullables := make(map[string]bool)
+	for _, c := range f.Columns() {
+		nullables[c.Name] = c.Nullable
+	}
+	for _, k := range keys {
+		nonUnique := int64(1)
+		if k.Type == frm.PrimaryKey || k.Type == frm.UniqueKey {
+			nonUnique = 0
+		}
+		for i, p := range k.Parts {
+			collation, subPart := interface{}("A"), interface{}(nil)
+			if p.Desc {
+				collation = "D"
+			}
+			if k.Type == frm.FullTextKey {
+				collation = nil
+			}
+			if p.Length > 0 {
+				subPart = int64(p.Length)
+			}
+			null := emptyString
+			if nullables[p.Column] {
+				null = "YES"
+			}
+			s.Statistics.add(catalog, database, table, nonUnique, database, k.Name, int64(i+1),
+				p.Column, collation, subPart, null, indexType(&k, f.Engine()), k.Comment)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) loadView(path, database, table string) error {
+	v, err := frm.NewView(path)
+	if err == frm.WrongParseFileErr {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.Tables.add(catalog, database, table, systemView, nil, nil, nil, systemView)
+	s.Views.add(catalog, database, table, v.Query, v.CheckOption, yesNo(v.Updatable), v.Definer,
+		v.Security, v.ClientCharset, v.Collation)
+	return nil
+}
+
+func (s *Schema) loadTriggers(path, database string) error {
+	triggers, err := frm.ReadTriggers(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, t := range triggers {
+		created := interface{}(nil)
+		if !t.Created.IsZero() {
+			created = t.Created.Format(createdTime)
+		}
+		s.Triggers.add(catalog, database, t.Name, t.Event, catalog, database, t.Table, int64(t.Order),
+			t.Statement, "ROW", t.Timing, created, t.SQLMode, t.Definer, t.ClientCharset, t.Collation,
+			t.DatabaseCollation)
+	}
+	return nil
+}
+
+// columnKeys returns the COLUMN_KEY values: PRI for the columns of the
+// primary key, UNI for the column of a single column unique key and MUL
+// for the first column of other keys. Without a primary key, the first
+// unique key of NOT NULL columns is shown as one.
+func columnKeys(columns []frm.Column, keys []frm.Key) map[string]string {
+	nullables := make(map[string]bool)
+	for _, c := range columns {
+		nullables[c.Name] = c.Nullable
+	}
+	primary := -1
+	for i, k := range keys {
+		if k.Type == frm.PrimaryKey {
+			primary = i
+			break
+		}
+	}
+	for i := 0; i < len(keys) && primary < 0; i++ {
+		if keys[i].Type != frm.UniqueKey {
+			continue
+		}
+		notNull := true
+		for _, p := range keys[i].Parts {
+			if nullables[p.Column] || p.Length > 0 {
+				notNull = false
+			}
+		}
+		if notNull {
+			primary = i
+		}
+	}
+	res := make(map[string]string)
+	for i, k := range keys {
+		if i == primary {
+			for _, p := range k.Parts {
+				res[p.Column] = "PRI"
+			}
+			continue
+		}
+		if len(k.Parts) == 0 || res[k.Parts[0].Column] != emptyString {
+			continue
+		}
+		if k.Type == frm.UniqueKey && len(k.Parts) == 1 {
+			res[k.Parts[0].Column] = "UNI"
+		} else {
+			res[k.Parts[0].Column] = "MUL"
+		}
+	}
+	return res
+}
+
+// dataType returns the type name of a column type.
+func dataType(s string) string {
+	if i := strings.IndexAny(s, "( "); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+func indexType(k *frm.Key, engine string) string {
+	switch {
+	case k.Type == frm.FullTextKey:
+		return "FULLTEXT"
+	case k.Type == frm.SpatialKey:
+		return "SPATIAL"
+	case k.Algorithm != emptyString:
+		return k.Algorithm
+	case strings.EqualFold(engine, memoryEngine):
+		return hashIndex
+	}
+	return bTreeIndex
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "YES"
+	}
+	return "NO"
+}
+
+func nullable(s string) interface{} {
+	if s == emptyString {
+		return nil
+	}
+	return s
+}
+
+// Dump writes every table of the schema to dir, in files named after
+// the tables.
+func (s *Schema) Dump(dir string, format Format) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, rs := range s.Rowsets() {
+		ext, write := ".csv", rs.WriteCSV
+		if format == JSON {
+			ext, write = ".json", rs.WriteJSON
+		}
+		file, err := os.Create(filepath.Join(dir, rs.Name+ext))
+		if err != nil {
+			return err
+		}
+		err = write(file)
+		if cerr := file.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}